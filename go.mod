@@ -4,6 +4,8 @@ go 1.14
 
 require (
 	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
+	github.com/hashicorp/go-hclog v0.15.0
+	github.com/hashicorp/terraform-plugin-go v0.1.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.4.0
 	github.com/jeremmfr/go-netconf v0.3.1
 	github.com/jeremmfr/junosdecode v1.0.0
@@ -0,0 +1,189 @@
+package junos
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// adoptUnsupported : resources without adopt_existing
+// (configured on an existing interface or a singleton of configuration, create never fails on existing object).
+var adoptUnsupported = map[string]bool{
+	"junos_interface":                  true,
+	"junos_interface_logical":          true,
+	"junos_interface_physical":         true,
+	"junos_interface_st0_unit":         true,
+	"junos_routing_options":            true,
+	"junos_security":                   true,
+	"junos_system":                     true,
+	"junos_system_root_authentication": true,
+}
+
+// resourcesWithAdopt adds the adopt_existing argument to resources.
+// With adopt_existing (on resource or provider), create takes over an object already on the device
+// (deleted then set with the attributes of resource) instead of failing
+// and the existing object is read before create to list in the warning its attributes not kept.
+func resourcesWithAdopt(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for resourceType, res := range resources {
+		if adoptUnsupported[resourceType] {
+			continue
+		}
+		if res.Schema == nil {
+			res.Schema = make(map[string]*schema.Schema)
+		}
+		res.Schema["adopt_existing"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			// only used on create
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return d.Id() != ""
+			},
+			// required by resources without update (but changes are suppressed)
+			ForceNew: res.UpdateContext == nil,
+		}
+		res.CreateContext = adoptCreate(resourceType, res, res.CreateContext, res.ReadContext)
+	}
+
+	return resources
+}
+
+// adoptExisting returns true if create of d can adopt an existing object
+// (adopt_existing set on resource or provider).
+func (sess *Session) adoptExisting(d *schema.ResourceData) bool {
+	if sess.junosAdoptExisting {
+		return true
+	}
+	v, ok := d.GetOk("adopt_existing")
+
+	return ok && v.(bool)
+}
+
+// adoptedWarning returns the warning of create when the object already exists (existsErr) has been adopted.
+func adoptedWarning(resourceType string, existsErr error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  adoptedSummary(resourceType),
+		Detail: existsErr.Error() + ", its configuration has been deleted and replaced " +
+			"by the attributes of resource (adopt_existing)",
+	}
+}
+
+func adoptedSummary(resourceType string) string {
+	return "existing configuration adopted by " + resourceType
+}
+
+// adoptCreate reads the existing object (with the read of resource and the attributes of create)
+// before create with adopt_existing, then adds in the adopted warning the attributes
+// of the existing object replaced or removed by create.
+func adoptCreate(resourceType string, res *schema.Resource,
+	create, read func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		sess := m.(*Session)
+		if !sess.adoptExisting(d) {
+			return create(ctx, d, m)
+		}
+		existing := adoptReadExisting(ctx, resourceType, res, read, d, m)
+		diags := create(ctx, d, m)
+		if existing == nil || existing.Id() == "" {
+			return diags
+		}
+		for i := range diags {
+			if diags[i].Severity == diag.Warning && diags[i].Summary == adoptedSummary(resourceType) {
+				diags[i].Detail += adoptedAttributes(res, existing, d)
+			}
+		}
+
+		return diags
+	}
+}
+
+// adoptReadExisting returns the existing object read with the attributes of create
+// (nil, without failing the create, if it can't be read).
+func adoptReadExisting(ctx context.Context, resourceType string, res *schema.Resource,
+	read func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+	d *schema.ResourceData, m interface{}) *schema.ResourceData {
+	sess := m.(*Session)
+	existing := res.Data(nil)
+	for k := range res.Schema {
+		if err := existing.Set(k, d.Get(k)); err != nil {
+			sess.log().Warn("failed to set attribute to read existing configuration before adopt",
+				"resource", resourceType, "attribute", k, "error", err)
+
+			return nil
+		}
+	}
+	existing.SetId("adopt_existing")
+	if diags := read(ctx, existing, m); diags.HasError() {
+		sess.log().Warn("failed to read existing configuration before adopt", "resource", resourceType,
+			"error", diags[0].Summary)
+
+		return nil
+	}
+
+	return existing
+}
+
+// adoptedAttributes returns the detail of adopted warning with the attributes of existing object
+// which are not kept in d.
+func adoptedAttributes(res *schema.Resource, existing, d *schema.ResourceData) string {
+	replaced := make([]string, 0)
+	for k := range res.Schema {
+		if k == "adopt_existing" || k == "device" || k == "junos_diff" {
+			continue
+		}
+		existingValue := adoptComparable(existing.Get(k))
+		if adoptEmpty(existingValue) {
+			continue
+		}
+		if !reflect.DeepEqual(existingValue, adoptComparable(d.Get(k))) {
+			replaced = append(replaced, k)
+		}
+	}
+	if len(replaced) == 0 {
+		return "\nAll attributes of the existing configuration have been kept."
+	}
+	sort.Strings(replaced)
+
+	return "\nAttributes of the existing configuration replaced or removed : " + strings.Join(replaced, ", ")
+}
+
+func adoptEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Map {
+		return value.Len() == 0
+	}
+
+	return value.IsZero()
+}
+
+// adoptComparable returns the value of attribute with lists instead of sets to compare values.
+func adoptComparable(v interface{}) interface{} {
+	switch value := v.(type) {
+	case *schema.Set:
+		return adoptComparable(value.List())
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, e := range value {
+			list[i] = adoptComparable(e)
+		}
+
+		return list
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, e := range value {
+			m[k] = adoptComparable(e)
+		}
+
+		return m
+	default:
+		return v
+	}
+}
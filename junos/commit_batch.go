@@ -0,0 +1,284 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// commitBatchSettle : delay before the commit of a batch when no session is collecting changes
+// (actions of the same group started just after can join the batch).
+const commitBatchSettle = 200 * time.Millisecond
+
+// commitBatcher : collects the changes of resources to commit them together.
+type commitBatcher struct {
+	// collecting : number of sessions collecting changes not yet added to a batch.
+	collecting int
+	idle       time.Duration
+	mutex      sync.Mutex
+	current    *commitBatch
+}
+
+// commitBatch : changes waiting for the same commit.
+type commitBatch struct {
+	deadline time.Time
+	timer    *time.Timer
+	entries  []*commitBatchEntry
+	done     chan struct{}
+}
+
+// commitBatchEntry : changes of a resource action in a batch and the result of their commit.
+type commitBatchEntry struct {
+	lines   []string
+	message string
+	warns   []error
+	err     error
+}
+
+func newCommitBatcher(idleSeconds int) *commitBatcher {
+	return &commitBatcher{
+		idle: time.Duration(idleSeconds) * time.Second,
+	}
+}
+
+// begin counts a session which starts to collect changes.
+func (b *commitBatcher) begin() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.collecting++
+	if b.current != nil {
+		b.current.timer.Reset(b.idle)
+	}
+}
+
+// leave counts a session which stops to collect changes without adding them to a batch
+// and delays the commit of the current batch to commitBatchSettle if no other session is still collecting.
+func (b *commitBatcher) leave() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.collecting--
+	if b.collecting <= 0 && b.current != nil {
+		b.current.timer.Reset(b.settle())
+	}
+}
+
+// settle returns the delay before the commit of a batch when no session is collecting changes.
+func (b *commitBatcher) settle() time.Duration {
+	if b.idle < commitBatchSettle {
+		return b.idle
+	}
+
+	return commitBatchSettle
+}
+
+// add adds the lines of a resource action to the current batch (or a new batch).
+// The batch is committed shortly after the end of the group of actions (no session collecting changes)
+// or after the idle timeout without new changes.
+func (b *commitBatcher) add(sess *Session, lines []string, message string, deadline time.Time) (
+	*commitBatch, *commitBatchEntry) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.collecting--
+	delay := b.idle
+	if b.collecting <= 0 {
+		// end of the group of parallel actions
+		delay = b.settle()
+	}
+	if b.current == nil {
+		batch := &commitBatch{
+			done: make(chan struct{}),
+		}
+		batch.timer = time.AfterFunc(delay, func() { b.commit(sess, batch) })
+		b.current = batch
+	} else {
+		b.current.timer.Reset(delay)
+	}
+	batch := b.current
+	entry := &commitBatchEntry{
+		lines:   lines,
+		message: message,
+	}
+	batch.entries = append(batch.entries, entry)
+	if !deadline.IsZero() && (batch.deadline.IsZero() || deadline.Before(batch.deadline)) {
+		batch.deadline = deadline
+	}
+
+	return batch, entry
+}
+
+// flush commits the current batch now and waits for the end of its commit.
+func (b *commitBatcher) flush(sess *Session) {
+	b.mutex.Lock()
+	batch := b.current
+	b.mutex.Unlock()
+	if batch == nil {
+		return
+	}
+	b.commit(sess, batch)
+	<-batch.done
+}
+
+// commit detaches the batch (new changes go to a new batch) then commits it.
+// If the commit fails with an error on the configuration of some resource actions,
+// the batch is committed again without them so only these actions get the error.
+func (b *commitBatcher) commit(sess *Session, batch *commitBatch) {
+	b.mutex.Lock()
+	if b.current != batch {
+		// already committed by a previous expiration of the timer or a flush
+		b.mutex.Unlock()
+
+		return
+	}
+	b.current = nil
+	batch.timer.Stop()
+	b.mutex.Unlock()
+
+	pending := batch.entries
+	for len(pending) > 0 {
+		warns, err := sess.commitBatch(batch.deadline, pending)
+		if err == nil {
+			for _, entry := range pending {
+				entry.warns = warns
+			}
+
+			break
+		}
+		causes, others := commitBatchCauses(pending, err)
+		if len(causes) == 0 || len(others) == 0 {
+			err = fmt.Errorf("batch commit of %d resource action(s) (%s) failed : %w",
+				len(pending), commitBatchMessages(pending), err)
+			for _, entry := range pending {
+				entry.warns, entry.err = warns, err
+			}
+
+			break
+		}
+		err = fmt.Errorf("batch commit of %d resource action(s) (%s) failed on the configuration of %s : %w",
+			len(pending), commitBatchMessages(pending), commitBatchMessages(causes), err)
+		for _, entry := range causes {
+			entry.warns, entry.err = warns, err
+		}
+		sess.log().Warn("batch commit failed, commit again without the resource actions in error",
+			"log_messages", commitBatchMessages(causes), "error", err)
+		pending = others
+	}
+	close(batch.done)
+}
+
+// commitBatchCauses splits entries between the entries with a line under the configuration path in error
+// and the others (no causes if the error isn't on a configuration path).
+func commitBatchCauses(entries []*commitBatchEntry, err error) (causes, others []*commitBatchEntry) {
+	var commitErr *commitRPCError
+	if !errors.As(err, &commitErr) {
+		return nil, entries
+	}
+	path := strings.Fields(strings.TrimPrefix(commitErr.path, "edit "))
+	if len(path) == 0 {
+		return nil, entries
+	}
+	for _, entry := range entries {
+		cause := false
+		for _, line := range entry.lines {
+			words := splitConfigWords(line)
+			if len(words) > 1 && configWordsHasPrefix(words[1:], path) {
+				cause = true
+
+				break
+			}
+		}
+		if cause {
+			causes = append(causes, entry)
+		} else {
+			others = append(others, entry)
+		}
+	}
+
+	return causes, others
+}
+
+func commitBatchMessages(entries []*commitBatchEntry) string {
+	messages := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		messages = append(messages, entry.message)
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// commitBatch loads the lines of entries and commits them with a new session
+// (sessions of the resources are waiting for the result and can't be used).
+func (sess *Session) commitBatch(deadline time.Time, entries []*commitBatchEntry) (_warnings []error, _err error) {
+	ctx := context.Background()
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+	lines := make([]string, 0)
+	applyGroups := make(map[string]bool)
+	for _, entry := range entries {
+		for _, line := range entry.lines {
+			// the group is applied once for all entries
+			if strings.HasPrefix(line, "set apply-groups ") {
+				if applyGroups[line] {
+					continue
+				}
+				applyGroups[line] = true
+			}
+			lines = append(lines, line)
+		}
+	}
+	messages := commitBatchMessages(entries)
+	sess.log().Debug("batch commit", "lines", len(lines), "log_messages", messages)
+	jnpr, err := sess.dialNewSession(ctx)
+	if err != nil {
+		if jnpr != nil {
+			_ = jnpr.Close(sess.junosSleepSSHClosed)
+		}
+
+		return []error{}, err
+	}
+	defer func() {
+		_ = jnpr.Close(sess.junosSleepSSHClosed)
+	}()
+	if err := sess.lockCandidate(ctx, jnpr); err != nil {
+		return []error{}, err
+	}
+	if err := sess.loadConfig(lines, jnpr); err != nil {
+		if clearErr := sess.configClear(jnpr); clearErr != nil {
+			return []error{}, fmt.Errorf("%w, %s", err, clearErr)
+		}
+
+		return []error{}, err
+	}
+	warns, err := sess.commitNow(messages, jnpr)
+	if err != nil {
+		if clearErr := sess.configClear(jnpr); clearErr != nil {
+			return warns, fmt.Errorf("%w, %s", err, clearErr)
+		}
+
+		return warns, err
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		jnpr.log().Warn("failed to clear candidate configuration after batch commit", "error", err)
+	}
+
+	return warns, nil
+}
+
+// wait waits for the commit of the batch and returns the result of entry.
+// At the end of ctx, the batch is committed without waiting the other resource actions.
+func (batch *commitBatch) wait(ctx context.Context, b *commitBatcher, sess *Session,
+	entry *commitBatchEntry) (_warnings []error, _err error) {
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		b.commit(sess, batch)
+		<-batch.done
+	}
+
+	return entry.warns, entry.err
+}
@@ -1,40 +1,117 @@
 package junos
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 // Config : provider config.
 type Config struct {
-	junosPort                int
-	junosCmdSleepShort       int
-	junosCmdSleepLock        int
-	junosSSHSleepClosed      int
-	junosIP                  string
-	junosUserName            string
-	junosPassword            string
-	junosSSHKeyPEM           string
-	junosSSHKeyFile          string
-	junosKeyPass             string
-	junosGroupIntDel         string
-	junosDebugNetconfLogPath string
+	junosSSHInsecure          bool
+	junosCommitCheckPlan      bool
+	junosConfigPrivate        bool
+	junosBatchCommit          bool
+	junosDiffPlan             bool
+	junosAdoptExisting        bool
+	junosPort                 int
+	junosCmdSleepShort        int
+	junosCmdSleepLock         int
+	junosSSHSleepClosed       int
+	junosSSHPoolSize          int
+	junosCommitConfirmed      int
+	junosLockTimeout          int
+	junosBatchCommitIdle      int
+	junosRetryCount           int
+	junosRetryBackoff         int
+	junosConfigSnapshotTTL    int
+	junosReadParallelism      int
+	junosIP                   string
+	junosUserName             string
+	junosPassword             string
+	junosSSHKeyPEM            string
+	junosSSHKeyFile           string
+	junosKeyPass              string
+	junosGroupIntDel          string
+	junosLogicalSystem        string
+	junosTenant               string
+	junosConfigGroup          string
+	junosDebugNetconfLogPath  string
+	junosSSHKnownHosts        string
+	junosCommitConfirmedCheck string
+	junosDiffAuditFile        string
+	junosSSHFingerprints      []string
+	junosDevices              []deviceConfig
+	junosBastion              *netconfBastion
 }
 
 // Session : read session information for Junos Device.
 func (c *Config) Session() (*Session, diag.Diagnostics) {
 	sess := &Session{
-		junosIP:             c.junosIP,
-		junosPort:           c.junosPort,
-		junosUserName:       c.junosUserName,
-		junosPassword:       c.junosPassword,
-		junosSSHKeyPEM:      c.junosSSHKeyPEM,
-		junosSSHKeyFile:     c.junosSSHKeyFile,
-		junosKeyPass:        c.junosKeyPass,
-		junosGroupIntDel:    c.junosGroupIntDel,
-		junosLogFile:        c.junosDebugNetconfLogPath,
-		junosSleepLock:      c.junosCmdSleepLock,
-		junosSleepShort:     c.junosCmdSleepShort,
-		junosSleepSSHClosed: c.junosSSHSleepClosed,
+		junosSSHInsecure:          c.junosSSHInsecure,
+		junosCommitCheckPlan:      c.junosCommitCheckPlan,
+		junosConfigPrivate:        c.junosConfigPrivate,
+		junosDiffPlan:             c.junosDiffPlan,
+		junosAdoptExisting:        c.junosAdoptExisting,
+		junosIP:                   c.junosIP,
+		junosPort:                 c.junosPort,
+		junosUserName:             c.junosUserName,
+		junosPassword:             c.junosPassword,
+		junosSSHKeyPEM:            c.junosSSHKeyPEM,
+		junosSSHKeyFile:           c.junosSSHKeyFile,
+		junosKeyPass:              c.junosKeyPass,
+		junosGroupIntDel:          c.junosGroupIntDel,
+		junosLogicalSystem:        c.junosLogicalSystem,
+		junosTenant:               c.junosTenant,
+		junosConfigGroup:          c.junosConfigGroup,
+		junosSleepLock:            c.junosCmdSleepLock,
+		junosLockTimeout:          c.junosLockTimeout,
+		junosRetryCount:           c.junosRetryCount,
+		junosRetryBackoff:         c.junosRetryBackoff,
+		junosSleepShort:           c.junosCmdSleepShort,
+		junosSleepSSHClosed:       c.junosSSHSleepClosed,
+		junosSSHKnownHosts:        c.junosSSHKnownHosts,
+		junosSSHFingerprints:      c.junosSSHFingerprints,
+		junosBastion:              c.junosBastion,
+		junosCommitConfirmed:      c.junosCommitConfirmed,
+		junosCommitConfirmedCheck: c.junosCommitConfirmedCheck,
+		junosDiffAuditFile:        c.junosDiffAuditFile,
+	}
+	logger, err := newLogger(c.junosDebugNetconfLogPath)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	sess.logger = logger.With("ip", c.junosIP)
+	if c.junosLogicalSystem != "" {
+		sess.logger = sess.logger.With("logical_system", c.junosLogicalSystem)
+	}
+	if c.junosTenant != "" {
+		sess.logger = sess.logger.With("tenant", c.junosTenant)
+	}
+	if c.junosBatchCommit {
+		sess.commitBatcher = newCommitBatcher(c.junosBatchCommitIdle)
+	}
+	if c.junosIP != "" && c.junosReadParallelism > 0 {
+		sess.control = deviceControlFor(c.junosIP+":"+strconv.Itoa(c.junosPort), c.junosReadParallelism)
+	}
+	if c.junosConfigSnapshotTTL > 0 {
+		sess.configSnapshot = newConfigSnapshot(c.junosConfigSnapshotTTL)
+	}
+	if c.junosSSHPoolSize > 0 {
+		sess.netconfPool = newNetconfPool(c.junosSSHPoolSize)
+	}
+	if len(c.junosDevices) > 0 {
+		sess.devices = make(map[string]*Session, len(c.junosDevices))
+		for _, device := range c.junosDevices {
+			if strings.Contains(device.name, deviceSeparator) {
+				return nil, diag.Errorf("name of device %q can't contain %q", device.name, deviceSeparator)
+			}
+			if _, ok := sess.devices[device.name]; ok {
+				return nil, diag.Errorf("multiple device blocks with the same name %q", device.name)
+			}
+			sess.devices[device.name] = sess.newDeviceSession(device, c.junosSSHPoolSize, c.junosReadParallelism)
+		}
 	}
 
 	return sess, nil
@@ -0,0 +1,69 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// diffAuditMutex : serialize writes in diff_audit_file.
+var diffAuditMutex = &sync.Mutex{}
+
+// resourcesWithDiff adds the junos_diff attribute to resources.
+// With diff_on_plan, junos_diff is set during plan (by customizeDiffCommitCheck)
+// with the output of 'show | compare' of the planned configuration lines.
+func resourcesWithDiff(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, res := range resources {
+		if res.Schema == nil {
+			res.Schema = make(map[string]*schema.Schema)
+		}
+		res.Schema["junos_diff"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+		res.CustomizeDiff = diffCustomizeDiff(res.CustomizeDiff)
+	}
+
+	return resources
+}
+
+// diffCustomizeDiff runs customizeDiff then, for a new resource without diff on plan,
+// sets junos_diff to an empty value to not display it as known after apply.
+func diffCustomizeDiff(customizeDiff schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, diff, m); err != nil {
+				return err
+			}
+		}
+		if diff.Id() == "" && !diff.NewValueKnown("junos_diff") {
+			return diff.SetNew("junos_diff", "")
+		}
+
+		return nil
+	}
+}
+
+// writeDiffAudit appends the differences of a commit in diff_audit_file.
+func (sess *Session) writeDiffAudit(logMessage, compare string) error {
+	diffAuditMutex.Lock()
+	defer diffAuditMutex.Unlock()
+	f, err := os.OpenFile(sess.junosDiffAuditFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open diff audit file : %w", err)
+	}
+	_, err = fmt.Fprintf(f, "# %s %s : %s\n%s\n\n",
+		time.Now().Format("2006-01-02 15:04:05 -0700"), sess.junosIP, logMessage, compare)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write diff audit file : %w", err)
+	}
+
+	return nil
+}
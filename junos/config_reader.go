@@ -0,0 +1,141 @@
+package junos
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// configElement : element of a configuration path,
+// with the name of the entry (in the name element) for an element of list.
+type configElement struct {
+	name string
+	key  string
+}
+
+// xmlFlag : configuration element without value (like <discard/>), true if present.
+type xmlFlag bool
+
+// UnmarshalXML sets the flag when the element is present.
+func (f *xmlFlag) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*f = true
+
+	return d.Skip()
+}
+
+// xmlMetricValue : configuration element with an integer value directly in the element
+// or in a metric-value element (like <preference><metric-value>5</metric-value></preference>).
+type xmlMetricValue struct {
+	Value       string `xml:",chardata"`
+	MetricValue string `xml:"metric-value"`
+}
+
+// Int returns the value of element (0 if not set).
+func (v xmlMetricValue) Int() (int, error) {
+	value := strings.TrimSpace(v.MetricValue)
+	if value == "" {
+		value = strings.TrimSpace(v.Value)
+	}
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert value from '%s' to integer : %w", value, err)
+	}
+
+	return i, nil
+}
+
+// readConfigXML reads the configuration under path (with the configuration group, logical system or tenant prefix)
+// with a get-configuration rpc and a subtree filter, then decodes the last element of path in v.
+// Like 'show configuration', the candidate configuration of the session is read
+// (or the configuration snapshot when the session has no changes in progress).
+// found is false if the element doesn't exist.
+func (sess *Session) readConfigXML(path []configElement, v interface{}, jnpr *NetconfObject) (found bool, err error) {
+	if sess.junosLogicalSystem != "" {
+		path = append([]configElement{{name: "logical-systems", key: sess.junosLogicalSystem}}, path...)
+	} else if sess.junosTenant != "" {
+		path = append([]configElement{{name: "tenants", key: sess.junosTenant}}, path...)
+	}
+	if sess.junosConfigGroup != "" {
+		path = append([]configElement{{name: "groups", key: sess.junosConfigGroup}}, path...)
+	}
+	var reply string
+	if sess.useConfigSnapshot(jnpr) {
+		reply, err = sess.snapshotXML(jnpr)
+	} else {
+		reply, err = sess.commandXML(fmt.Sprintf(rpcGetConfig, configFilter(path)), jnpr)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return decodeConfigXML(reply, append([]configElement{{name: "configuration"}}, path...), v)
+}
+
+// configFilter returns the subtree filter to select the element of path.
+func configFilter(path []configElement) string {
+	var filter strings.Builder
+	for _, elem := range path {
+		filter.WriteString("<" + elem.name + ">")
+		if elem.key != "" {
+			filter.WriteString("<name>")
+			_ = xml.EscapeText(&filter, []byte(elem.key))
+			filter.WriteString("</name>")
+		}
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		filter.WriteString("</" + path[i].name + ">")
+	}
+
+	return filter.String()
+}
+
+// decodeConfigXML finds the element of path in data (with the name element equal to key for a list)
+// and decodes it in v.
+// found is false if the element doesn't exist.
+func decodeConfigXML(data string, path []configElement, v interface{}) (found bool, err error) {
+	decoder := xml.NewDecoder(strings.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to xml decode configuration : %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local != path[0].name {
+			if err := decoder.Skip(); err != nil {
+				return false, fmt.Errorf("failed to xml decode configuration : %w", err)
+			}
+
+			continue
+		}
+		var elem struct {
+			Name  string `xml:"name"`
+			Inner string `xml:",innerxml"`
+		}
+		if err := decoder.DecodeElement(&elem, &start); err != nil {
+			return false, fmt.Errorf("failed to xml decode configuration element %s : %w", path[0].name, err)
+		}
+		if path[0].key != "" && strings.TrimSpace(elem.Name) != path[0].key {
+			continue
+		}
+		if len(path) > 1 {
+			return decodeConfigXML(elem.Inner, path[1:], v)
+		}
+		if err := xml.Unmarshal([]byte("<"+path[0].name+">"+elem.Inner+"</"+path[0].name+">"), v); err != nil {
+			return false, fmt.Errorf("failed to xml decode configuration element %s : %w", path[0].name, err)
+		}
+
+		return true, nil
+	}
+}
@@ -0,0 +1,124 @@
+package junos
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestConfigFilter(t *testing.T) {
+	got := configFilter([]configElement{
+		{name: "routing-instances"},
+		{name: "instance", key: "ri&1"},
+		{name: "routing-options"},
+		{name: "static"},
+		{name: "route", key: "192.0.2.0/24"},
+	})
+	want := "<routing-instances><instance><name>ri&amp;1</name><routing-options><static>" +
+		"<route><name>192.0.2.0/24</name></route></static></routing-options></instance></routing-instances>"
+	if got != want {
+		t.Errorf("configFilter() = %q, want %q", got, want)
+	}
+}
+
+func TestXMLMetricValue(t *testing.T) {
+	for value, want := range map[xmlMetricValue]int{
+		{}:                              0,
+		{Value: " 5 "}:                  5,
+		{MetricValue: "10"}:             10,
+		{Value: "\n", MetricValue: "7"}: 7,
+	} {
+		got, err := value.Int()
+		if err != nil {
+			t.Errorf("Int() of %v: unexpected error %s", value, err)
+		}
+		if got != want {
+			t.Errorf("Int() of %v = %d, want %d", value, got, want)
+		}
+	}
+	if _, err := (xmlMetricValue{MetricValue: "high"}).Int(); err == nil {
+		t.Errorf("Int() of non-integer value: expected error")
+	}
+}
+
+func TestDecodeConfigXMLStaticRoute(t *testing.T) {
+	reply, err := ioutil.ReadFile("testdata/get_configuration_static_route.xml")
+	if err != nil {
+		t.Fatalf("failed to read recorded reply: %s", err)
+	}
+	instancePath := []configElement{
+		{name: "configuration"},
+		{name: "routing-instances"},
+		{name: "instance", key: "testacc_staticRoute"},
+		{name: "routing-options"},
+	}
+	pathTo := func(elems ...configElement) []configElement {
+		return append(append([]configElement{}, instancePath...), elems...)
+	}
+
+	var route staticRouteXML
+	found, err := decodeConfigXML(string(reply), pathTo(
+		configElement{name: "static"},
+		configElement{name: "route", key: "192.0.2.0/24"},
+	), &route)
+	if err != nil || !found {
+		t.Fatalf("decodeConfigXML(inet.0 route) = %t, %v", found, err)
+	}
+	if !route.Discard || !route.Active || route.Reject || route.NoInstall {
+		t.Errorf("unexpected flags for inet.0 route: %+v", route)
+	}
+	if metric, _ := route.Metric.Int(); metric != 20 {
+		t.Errorf("metric of inet.0 route = %d, want 20", metric)
+	}
+	if len(route.QualifiedNextHop) != 2 {
+		t.Fatalf("qualified-next-hop of inet.0 route = %+v, want 2 entries", route.QualifiedNextHop)
+	}
+	if qnh := route.QualifiedNextHop[0]; qnh.Name != "192.0.2.254" || qnh.Interface != "" {
+		t.Errorf("unexpected first qualified-next-hop: %+v", qnh)
+	}
+	if preference, _ := route.QualifiedNextHop[0].Preference.Int(); preference != 5 {
+		t.Errorf("preference of first qualified-next-hop = %d, want 5", preference)
+	}
+	if qnh := route.QualifiedNextHop[1]; qnh.Name != "st0.0" || qnh.Interface != "st0.0" {
+		t.Errorf("unexpected second qualified-next-hop: %+v", qnh)
+	}
+
+	var route6 staticRouteXML
+	found, err = decodeConfigXML(string(reply), pathTo(
+		configElement{name: "rib", key: "testacc_staticRoute.inet6.0"},
+		configElement{name: "static"},
+		configElement{name: "route", key: "2001:db8:85a3::/48"},
+	), &route6)
+	if err != nil || !found {
+		t.Fatalf("decodeConfigXML(inet6.0 route) = %t, %v", found, err)
+	}
+	if !reflect.DeepEqual(route6.NextHop, []string{"2001:db8:85a3::1", "2001:db8:85a3::2"}) {
+		t.Errorf("next-hop of inet6.0 route = %v", route6.NextHop)
+	}
+	if !reflect.DeepEqual(route6.Community, []string{"no-advertise"}) {
+		t.Errorf("community of inet6.0 route = %v", route6.Community)
+	}
+	if preference, _ := route6.Preference.Int(); preference != 100 || !route6.NoInstall || route6.Discard {
+		t.Errorf("unexpected inet6.0 route: %+v", route6)
+	}
+
+	for name, path := range map[string][]configElement{
+		"other_route":    pathTo(configElement{name: "static"}, configElement{name: "route", key: "198.51.100.0/24"}),
+		"other_instance": {{name: "configuration"}, {name: "routing-instances"}, {name: "instance", key: "other"}},
+		"empty_rib":      pathTo(configElement{name: "rib", key: "inet6.0"}),
+	} {
+		var v staticRouteXML
+		found, err := decodeConfigXML(string(reply), path, &v)
+		if err != nil || found {
+			t.Errorf("decodeConfigXML(%s) = %t, %v, want not found", name, found, err)
+		}
+	}
+	found, err = decodeConfigXML(`<configuration junos:changed-seconds="1613727841"/>`,
+		[]configElement{{name: "configuration"}, {name: "routing-options"}}, &route)
+	if err != nil || found {
+		t.Errorf("decodeConfigXML(empty configuration) = %t, %v, want not found", found, err)
+	}
+	if _, err := decodeConfigXML("<configuration><routing-options>", instancePath, &route); err == nil {
+		t.Errorf("decodeConfigXML(truncated reply): expected error")
+	}
+}
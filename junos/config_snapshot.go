@@ -0,0 +1,218 @@
+package junos
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// configSnapshot : full configuration of the device read once and shared by the reads of resources
+// until its ttl expires or a commit is done.
+type configSnapshot struct {
+	ttl      time.Duration
+	mutex    sync.Mutex
+	setTime  time.Time
+	xmlTime  time.Time
+	setLines []string
+	xml      string
+}
+
+func newConfigSnapshot(ttlSeconds int) *configSnapshot {
+	return &configSnapshot{
+		ttl: time.Duration(ttlSeconds) * time.Second,
+	}
+}
+
+// invalidate drops the configuration read (waits the end of a read in progress).
+func (s *configSnapshot) invalidate() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.setLines = nil
+	s.setTime = time.Time{}
+	s.xml = ""
+	s.xmlTime = time.Time{}
+}
+
+// useConfigSnapshot returns true if the reads of jnpr can be answered by the snapshot:
+// snapshot enabled and no changes in progress with the session
+// (with a lock, a private candidate or a batch, the reads need the candidate configuration).
+func (sess *Session) useConfigSnapshot(jnpr *NetconfObject) bool {
+	return sess.configSnapshot != nil && !jnpr.locked && !jnpr.private && !jnpr.batching
+}
+
+// invalidateConfigSnapshot drops the snapshot after a commit.
+func (sess *Session) invalidateConfigSnapshot() {
+	if sess.configSnapshot != nil {
+		sess.configSnapshot.invalidate()
+	}
+}
+
+// snapshotSetLines returns the lines of 'show configuration | display set' in snapshot
+// (read with jnpr if missing or expired).
+func (sess *Session) snapshotSetLines(jnpr *NetconfObject) ([]string, error) {
+	s := sess.configSnapshot
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.setLines != nil && time.Since(s.setTime) < s.ttl {
+		return s.setLines, nil
+	}
+	var read string
+	readStart := time.Now()
+	if err := sess.readRPC(jnpr, "read configuration snapshot", func() error {
+		var err error
+		read, err = jnpr.netconfCommand("show configuration | display set")
+
+		return err
+	}); err != nil && read != emptyWord {
+		return nil, fmt.Errorf("failed to read configuration snapshot : %w", err)
+	}
+	lines := make([]string, 0)
+	for _, line := range strings.Split(read, "\n") {
+		if words := splitConfigWords(line); len(words) > 1 && !strings.HasPrefix(words[0], "#") {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	s.setLines = lines
+	s.setTime = readStart
+	jnpr.log().Debug("configuration snapshot read", "format", "set", "lines", len(lines),
+		"duration", time.Since(readStart).String())
+
+	return s.setLines, nil
+}
+
+// snapshotXML returns the configuration in XML of snapshot (read with jnpr if missing or expired).
+func (sess *Session) snapshotXML(jnpr *NetconfObject) (string, error) {
+	s := sess.configSnapshot
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.xml != "" && time.Since(s.xmlTime) < s.ttl {
+		return s.xml, nil
+	}
+	readStart := time.Now()
+	var read string
+	if err := sess.readRPC(jnpr, "read configuration snapshot", func() error {
+		var err error
+		read, err = jnpr.netconfCommandXML(fmt.Sprintf(rpcGetConfig, ""))
+
+		return err
+	}); err != nil {
+		return "", fmt.Errorf("failed to read configuration snapshot : %w", err)
+	}
+	s.xml = read
+	s.xmlTime = readStart
+	jnpr.log().Debug("configuration snapshot read", "format", "xml", "length", len(read),
+		"duration", time.Since(readStart).String())
+
+	return s.xml, nil
+}
+
+// snapshotCommand answers to a 'show configuration <path> | display set [relative]' command with the snapshot.
+// ok is false if the command can't be answered by the snapshot (other command or other pipe).
+func (sess *Session) snapshotCommand(cmd string, jnpr *NetconfObject) (read string, ok bool, err error) {
+	words := splitConfigWords(cmd)
+	if len(words) < 2 || words[0] != "show" || words[1] != "configuration" {
+		return "", false, nil
+	}
+	path := make([]string, 0)
+	var pipe []string
+	for i, w := range words[2:] {
+		if w == "|" {
+			pipe = words[2+i+1:]
+
+			break
+		}
+		path = append(path, unquoteConfigWord(w))
+	}
+	relative := false
+	switch strings.Join(pipe, " ") {
+	case "display set":
+	case "display set relative":
+		relative = true
+	default:
+		return "", false, nil
+	}
+	lines, err := sess.snapshotSetLines(jnpr)
+	if err != nil {
+		return "", true, err
+	}
+	output := make([]string, 0)
+	found := false
+	for _, line := range lines {
+		lineWords := splitConfigWords(line)
+		if !configWordsHasPrefix(lineWords[1:], path) {
+			continue
+		}
+		found = true
+		switch {
+		case !relative:
+			output = append(output, line)
+		case len(lineWords)-1 > len(path):
+			output = append(output, lineWords[0]+" "+strings.Join(lineWords[1+len(path):], " "))
+		}
+	}
+	jnpr.log().Trace("command answered by configuration snapshot", "command", cmd)
+	if !found {
+		return emptyWord, true, nil
+	}
+
+	// same format as the output of command
+	return "<configuration-output>\n" + strings.Join(output, "\n") + "\n</configuration-output>", true, nil
+}
+
+// configWordsHasPrefix returns true if words (of a configuration line) starts with the words of path.
+func configWordsHasPrefix(words, path []string) bool {
+	if len(path) > len(words) {
+		return false
+	}
+	for i, w := range path {
+		if unquoteConfigWord(words[i]) != w {
+			return false
+		}
+	}
+
+	return true
+}
+
+// splitConfigWords splits a configuration line (or a command) in words,
+// a quoted string is one word (with the quotes).
+func splitConfigWords(line string) []string {
+	words := make([]string, 0)
+	var word strings.Builder
+	quoted := false
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+			word.WriteRune(r)
+		case r == '\\' && quoted:
+			escaped = true
+			word.WriteRune(r)
+		case r == '"':
+			quoted = !quoted
+			word.WriteRune(r)
+		case (r == ' ' || r == '\t' || r == '\r') && !quoted:
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+		default:
+			word.WriteRune(r)
+		}
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+
+	return words
+}
+
+// unquoteConfigWord removes the quotes of a quoted word.
+func unquoteConfigWord(word string) string {
+	if len(word) >= 2 && strings.HasPrefix(word, `"`) && strings.HasSuffix(word, `"`) {
+		return word[1 : len(word)-1]
+	}
+
+	return word
+}
@@ -0,0 +1,294 @@
+package junos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const rpcCommandXML = "<command format=\"xml\">%s</command>"
+
+func dataSourceCommand() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCommandRead,
+		Schema: map[string]*schema.Schema{
+			"command": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"command", "rpc"},
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"rpc": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"command", "rpc"},
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"allow_changes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"output_xml": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"output_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCommandRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	rpc := d.Get("rpc").(string)
+	rpcText := ""
+	if command := d.Get("command").(string); command != "" {
+		if !d.Get("allow_changes").(bool) {
+			if err := checkCommandReadOnly(command); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		var buf bytes.Buffer
+		if err := xml.EscapeText(&buf, []byte(strings.TrimSpace(command))); err != nil {
+			return diag.FromErr(err)
+		}
+		rpc = fmt.Sprintf(rpcCommandXML, buf.String())
+		rpcText = fmt.Sprintf(rpcCommand, buf.String())
+	} else if !d.Get("allow_changes").(bool) {
+		if err := checkRPCReadOnly(rpc); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	reply, output, outputMap, err := readCommandOutput(rpc, rpcText, m, jnprSess)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	outputJSON, err := json.Marshal(outputMap)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to convert reply to json : %w", err))
+	}
+
+	if d.Get("command").(string) != "" {
+		d.SetId(d.Get("command").(string))
+	} else {
+		d.SetId(rpc)
+	}
+	if tfErr := d.Set("output", output); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("output_xml", strings.TrimSpace(reply)); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("output_json", string(outputJSON)); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+// readCommandOutput runs rpc and returns the xml reply, the text and the map of reply.
+// If rpcText is set, the text is the reply of rpcText (command with text format).
+func readCommandOutput(rpc, rpcText string, m interface{}, jnprSess *NetconfObject) (
+	string, string, map[string]interface{}, error) {
+	sess := m.(*Session)
+	reply, err := sess.commandXML(rpc, jnprSess)
+	if err != nil {
+		return "", "", nil, err
+	}
+	output, outputMap, err := parseCommandReply(reply)
+	if err != nil {
+		return "", "", nil, err
+	}
+	if rpcText != "" {
+		replyText, err := sess.commandXML(rpcText, jnprSess)
+		if err != nil {
+			return "", "", nil, err
+		}
+		output, _, err = parseCommandReply(replyText)
+		if err != nil {
+			return "", "", nil, err
+		}
+	}
+
+	return reply, output, outputMap, nil
+}
+
+// checkCommandReadOnly returns an error if command can change the device
+// (only show, ping, traceroute, file show and file list commands are allowed,
+// without pipe to save the output in a file).
+func checkCommandReadOnly(command string) error {
+	words := strings.Fields(command)
+	if len(words) == 0 {
+		return errors.New("command is empty")
+	}
+	switch {
+	case words[0] == "show", words[0] == "ping", words[0] == "traceroute":
+	case words[0] == "file" && len(words) > 1 && (words[1] == "show" || words[1] == "list"):
+	default:
+		return fmt.Errorf("command %q can change the device, only show, ping, traceroute, file show and file list "+
+			"are allowed without allow_changes", command)
+	}
+	for i, w := range words {
+		if w == "|" && i+1 < len(words) {
+			switch words[i+1] {
+			case "save", "append", "tee":
+				return fmt.Errorf("pipe %q in command %q can change the device and is not allowed without allow_changes",
+					words[i+1], command)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRPCReadOnly returns an error if rpc can change the device
+// (only one get-* rpc, file-show, file-list, ping, traceroute or command with an allowed command is allowed).
+func checkRPCReadOnly(rpc string) error {
+	decoder := xml.NewDecoder(strings.NewReader(rpc))
+	var start xml.StartElement
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("failed to find the rpc method in %q : %w", rpc, err)
+		}
+		if t, ok := token.(xml.StartElement); ok {
+			start = t
+
+			break
+		}
+		if t, ok := token.(xml.CharData); ok && len(bytes.TrimSpace(t)) > 0 {
+			return fmt.Errorf("unexpected text %q before the rpc method in %q", string(t), rpc)
+		}
+	}
+	switch name := start.Name.Local; {
+	case strings.HasPrefix(name, "get-"),
+		name == "file-show", name == "file-list", name == "ping", name == "traceroute":
+		if err := decoder.Skip(); err != nil {
+			return fmt.Errorf("failed to read rpc %q : %w", rpc, err)
+		}
+	case name == "command":
+		var command struct {
+			Text string `xml:",chardata"`
+		}
+		if err := decoder.DecodeElement(&command, &start); err != nil {
+			return fmt.Errorf("failed to read command in rpc %q : %w", rpc, err)
+		}
+		if err := checkCommandReadOnly(command.Text); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("rpc %q can change the device, only get-*, file-show, file-list, ping, traceroute "+
+			"and command rpc are allowed without allow_changes", name)
+	}
+	// the other elements after the method would be executed as other rpcs
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read rpc %q : %w", rpc, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			return fmt.Errorf("rpc %q has an other element %q after the method %q, only one rpc is allowed",
+				rpc, t.Name.Local, start.Name.Local)
+		case xml.CharData:
+			if len(bytes.TrimSpace(t)) > 0 {
+				return fmt.Errorf("unexpected text %q after the rpc method in %q", string(t), rpc)
+			}
+		}
+	}
+}
+
+// parseCommandReply returns the text and a map (element name as key, text or map as value
+// and list of values for repeated elements) of the xml reply.
+func parseCommandReply(reply string) (string, map[string]interface{}, error) {
+	decoder := xml.NewDecoder(strings.NewReader(reply))
+	var text strings.Builder
+	result := make(map[string]interface{})
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to xml decode reply : %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			value, err := parseCommandReplyElement(decoder, &text)
+			if err != nil {
+				return "", nil, err
+			}
+			addCommandReplyValue(result, t.Name.Local, value)
+		case xml.CharData:
+			text.Write(t)
+		}
+	}
+
+	return strings.TrimSpace(text.String()), result, nil
+}
+
+func parseCommandReplyElement(decoder *xml.Decoder, text *strings.Builder) (interface{}, error) {
+	children := make(map[string]interface{})
+	var charData strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to xml decode reply : %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			value, err := parseCommandReplyElement(decoder, text)
+			if err != nil {
+				return nil, err
+			}
+			addCommandReplyValue(children, t.Name.Local, value)
+		case xml.CharData:
+			charData.Write(t)
+			text.Write(t)
+		case xml.EndElement:
+			if len(children) > 0 {
+				return children, nil
+			}
+
+			return strings.TrimSpace(charData.String()), nil
+		}
+	}
+}
+
+func addCommandReplyValue(values map[string]interface{}, name string, value interface{}) {
+	current, ok := values[name]
+	if !ok {
+		values[name] = value
+
+		return
+	}
+	if list, ok := current.([]interface{}); ok {
+		values[name] = append(list, value)
+
+		return
+	}
+	values[name] = []interface{}{current, value}
+}
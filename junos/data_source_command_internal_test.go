@@ -0,0 +1,78 @@
+package junos
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckCommandReadOnly(t *testing.T) {
+	tests := map[string]bool{
+		"show version":                          true,
+		"show route table inet.0 | match 192.0": true,
+		"ping 192.0.2.1 count 1":                true,
+		"file show /var/log/messages":           true,
+		"show configuration | save /tmp/config": false,
+		"request system reboot":                 false,
+		"clear security flow session all":       false,
+		"restart routing":                       false,
+		"file delete /var/tmp/x":                false,
+	}
+	for command, allowed := range tests {
+		if err := checkCommandReadOnly(command); (err == nil) != allowed {
+			t.Errorf("checkCommandReadOnly(%q) = %v, want allowed %v", command, err, allowed)
+		}
+	}
+}
+
+func TestCheckRPCReadOnly(t *testing.T) {
+	tests := map[string]bool{
+		"<get-software-information/>":                                             true,
+		"<get-route-information><table>inet.0</table></get-route-information>":    true,
+		"<command>show version</command>":                                         true,
+		"<command>request system reboot</command>":                                false,
+		"<request-reboot/>":                                                       false,
+		"<clear-arp-table/>":                                                      false,
+		"<load-configuration action=\"set\"/>":                                    false,
+		"not xml":                                                                 false,
+		"<get-software-information/><request-reboot/>":                            false,
+		"<command>show version</command><command>request system reboot</command>": false,
+		"<get-software-information/>\n<clear-arp-table/>":                         false,
+		"<get-software-information/>request system reboot":                        false,
+		"show version <get-software-information/>":                                false,
+		"\n  <get-software-information/>\n":                                       true,
+	}
+	for rpc, allowed := range tests {
+		if err := checkRPCReadOnly(rpc); (err == nil) != allowed {
+			t.Errorf("checkRPCReadOnly(%q) = %v, want allowed %v", rpc, err, allowed)
+		}
+	}
+}
+
+func TestParseCommandReply(t *testing.T) {
+	reply := `
+<software-information>
+<host-name>srx1</host-name>
+<package-information><name>junos</name></package-information>
+<package-information><name>jkernel</name></package-information>
+</software-information>
+`
+	text, outputMap, err := parseCommandReply(reply)
+	if err != nil {
+		t.Fatalf("parseCommandReply: %s", err)
+	}
+	if text != "srx1\njunos\njkernel" {
+		t.Errorf("text = %q", text)
+	}
+	want := map[string]interface{}{
+		"software-information": map[string]interface{}{
+			"host-name": "srx1",
+			"package-information": []interface{}{
+				map[string]interface{}{"name": "junos"},
+				map[string]interface{}{"name": "jkernel"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(outputMap, want) {
+		t.Errorf("map = %v, want %v", outputMap, want)
+	}
+}
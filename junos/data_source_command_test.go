@@ -0,0 +1,36 @@
+package junos_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCommand_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCommandConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.junos_command.testacc_command", "output"),
+					resource.TestCheckResourceAttrSet("data.junos_command.testacc_command", "output_xml"),
+					resource.TestCheckResourceAttrSet("data.junos_command.testacc_command", "output_json"),
+					resource.TestCheckResourceAttrSet("data.junos_command.testacc_rpc", "output_xml"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCommandConfig() string {
+	return `
+data "junos_command" "testacc_command" {
+  command = "show interfaces terse"
+}
+data "junos_command" "testacc_rpc" {
+  rpc = "<get-system-information/>"
+}
+`
+}
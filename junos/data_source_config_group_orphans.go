@@ -0,0 +1,139 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceConfigGroupOrphans() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConfigGroupOrphansRead,
+		Schema: map[string]*schema.Schema{
+			"managed": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ids": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"resource_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"config_group": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"orphans": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConfigGroupOrphansRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosConfigGroup == "" {
+		return diag.FromErr(errors.New("config_group not set in provider configuration"))
+	}
+	resourceTypes := make([]string, 0)
+	for _, v := range d.Get("resource_types").(*schema.Set).List() {
+		if !generateSupported(v.(string)) {
+			return diag.Errorf("resource type %q not supported", v.(string))
+		}
+		resourceTypes = append(resourceTypes, v.(string))
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	orphans, err := readConfigGroupOrphans(d.Get("managed").([]interface{}), resourceTypes, m, jnprSess)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(sess.junosConfigGroup)
+	if tfErr := d.Set("config_group", sess.junosConfigGroup); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("orphans", orphans); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+// readConfigGroupOrphans returns the objects (resource type and import id) found in the configuration group
+// without a resource in managedResources (resource type and ids of resources,
+// with or without the device and logical system prefix).
+func readConfigGroupOrphans(managedResources []interface{}, resourceTypes []string, m interface{},
+	jnprSess *NetconfObject) ([]map[string]interface{}, error) {
+	sess := m.(*Session)
+	// the same import id can be used by objects of different resource types
+	managed := make(map[string]bool)
+	for _, mRes := range managedResources {
+		managedRes := mRes.(map[string]interface{})
+		for _, v := range managedRes["ids"].(*schema.Set).List() {
+			id := v.(string)
+			if i := strings.Index(id, deviceSeparator); i != -1 {
+				id = id[i+len(deviceSeparator):]
+			}
+			if i := strings.Index(id, logicalSystemSeparator); i != -1 {
+				id = id[i+len(logicalSystemSeparator):]
+			}
+			managed[managedRes["resource_type"].(string)+" "+id] = true
+		}
+	}
+	// with the prefix of configuration group, the lines of group are displayed without the prefix
+	groupConfig, err := sess.command("show configuration | display set", jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	orphans := make([]map[string]interface{}, 0)
+	if groupConfig == emptyWord {
+		return orphans, nil
+	}
+	ids := generateFindIDs(groupConfig, resourceTypes)
+	for _, matcher := range generateMatchers {
+		for _, id := range ids[matcher.resourceType] {
+			if managed[matcher.resourceType+" "+id] {
+				continue
+			}
+			orphans = append(orphans, map[string]interface{}{
+				"resource_type": matcher.resourceType,
+				"id":            id,
+			})
+		}
+	}
+
+	return orphans, nil
+}
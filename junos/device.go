@@ -0,0 +1,218 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deviceSeparator : separator between the device name and the id of resource.
+const deviceSeparator = "_@_"
+
+// deviceConfig : connection information of a device in device block of provider configuration,
+// empty values use the values of provider configuration.
+type deviceConfig struct {
+	sshInsecure     bool
+	port            int
+	name            string
+	ip              string
+	userName        string
+	password        string
+	sshKeyPEM       string
+	sshKeyFile      string
+	keyPass         string
+	sshKnownHosts   string
+	logicalSystem   string
+	tenant          string
+	sshFingerprints []string
+}
+
+// newDeviceSession returns a copy of sess (with its own pool of ssh connections, configuration snapshot
+// and concurrency control) to connect on the device.
+func (sess *Session) newDeviceSession(device deviceConfig, poolSize, readParallelism int) *Session {
+	devSess := *sess
+	devSess.devices = nil
+	devSess.junosIP = device.ip
+	devSess.junosSSHInsecure = sess.junosSSHInsecure || device.sshInsecure
+	if device.port != 0 {
+		devSess.junosPort = device.port
+	}
+	if device.userName != "" {
+		devSess.junosUserName = device.userName
+	}
+	if device.password != "" {
+		devSess.junosPassword = device.password
+	}
+	if device.sshKeyPEM != "" {
+		devSess.junosSSHKeyPEM = device.sshKeyPEM
+	}
+	if device.sshKeyFile != "" {
+		devSess.junosSSHKeyFile = device.sshKeyFile
+	}
+	if device.keyPass != "" {
+		devSess.junosKeyPass = device.keyPass
+	}
+	if device.sshKnownHosts != "" {
+		devSess.junosSSHKnownHosts = device.sshKnownHosts
+	}
+	devSess.logger = sess.log().With("device", device.name, "ip", device.ip)
+	if device.logicalSystem != "" || device.tenant != "" {
+		devSess.junosLogicalSystem = device.logicalSystem
+		devSess.junosTenant = device.tenant
+		devSess.logger = devSess.logger.With("logical_system", device.logicalSystem, "tenant", device.tenant)
+	}
+	if len(device.sshFingerprints) > 0 {
+		devSess.junosSSHFingerprints = device.sshFingerprints
+	}
+	devSess.netconfPool = nil
+	if poolSize > 0 {
+		devSess.netconfPool = newNetconfPool(poolSize)
+	}
+	if sess.commitBatcher != nil {
+		devSess.commitBatcher = newCommitBatcher(int(sess.commitBatcher.idle.Seconds()))
+	}
+	devSess.control = nil
+	if readParallelism > 0 {
+		devSess.control = deviceControlFor(device.ip+":"+strconv.Itoa(devSess.junosPort), readParallelism)
+	}
+	if sess.configSnapshot != nil {
+		devSess.configSnapshot = newConfigSnapshot(int(sess.configSnapshot.ttl.Seconds()))
+	}
+
+	return &devSess
+}
+
+// withLogFields returns a copy of sess with the fields added to the logger.
+func (sess *Session) withLogFields(args ...interface{}) *Session {
+	sessCopy := *sess
+	sessCopy.logger = sess.log().With(args...)
+
+	return &sessCopy
+}
+
+// device returns the session of a device in device block of provider configuration.
+func (sess *Session) device(name string) (*Session, error) {
+	devSess, ok := sess.devices[name]
+	if !ok {
+		return nil, fmt.Errorf("device %q not found in provider configuration", name)
+	}
+
+	return devSess, nil
+}
+
+// resourcesWithDevice adds the device argument to resources.
+// Actions on a resource with device use the session of the device
+// and the id in state is prefixed with the device name and deviceSeparator.
+// Logs of actions have the resource type in the resource field.
+func resourcesWithDevice(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for resourceType, res := range resources {
+		if res.Schema == nil {
+			res.Schema = make(map[string]*schema.Schema)
+		}
+		res.Schema["device"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		}
+		res.CreateContext = deviceResourceAction(resourceType, res.CreateContext)
+		res.ReadContext = deviceResourceAction(resourceType, res.ReadContext)
+		res.UpdateContext = deviceResourceAction(resourceType, res.UpdateContext)
+		res.DeleteContext = deviceResourceAction(resourceType, res.DeleteContext)
+		if res.CustomizeDiff != nil {
+			res.CustomizeDiff = deviceCustomizeDiff(resourceType, res.CustomizeDiff)
+		}
+		if res.Importer != nil && res.Importer.StateContext != nil {
+			res.Importer.StateContext = deviceImport(resourceType, res.Importer.StateContext)
+		}
+	}
+
+	return resources
+}
+
+// dataSourcesWithDevice adds the device argument to data sources.
+func dataSourcesWithDevice(dataSources map[string]*schema.Resource) map[string]*schema.Resource {
+	for dataSourceType, res := range dataSources {
+		res.Schema["device"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+		res.ReadContext = deviceResourceAction("data."+dataSourceType, res.ReadContext)
+	}
+
+	return dataSources
+}
+
+func deviceResourceAction(
+	resourceType string, action func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if action == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		device := d.Get("device").(string)
+		if device == "" {
+			return action(ctx, d, m.(*Session).withLogFields("resource", resourceType))
+		}
+		devSess, err := m.(*Session).device(device)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if d.Id() != "" {
+			d.SetId(strings.TrimPrefix(d.Id(), device+deviceSeparator))
+		}
+		diags := action(ctx, d, devSess.withLogFields("resource", resourceType))
+		if d.Id() != "" {
+			d.SetId(device + deviceSeparator + d.Id())
+		}
+
+		return diags
+	}
+}
+
+func deviceCustomizeDiff(resourceType string, customizeDiff schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		device := diff.Get("device").(string)
+		if device == "" {
+			return customizeDiff(ctx, diff, m.(*Session).withLogFields("resource", resourceType))
+		}
+		devSess, err := m.(*Session).device(device)
+		if err != nil {
+			return err
+		}
+
+		return customizeDiff(ctx, diff, devSess.withLogFields("resource", resourceType))
+	}
+}
+
+// deviceImport imports resource with an id prefixed by a device name and deviceSeparator.
+func deviceImport(resourceType string, importState schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if !strings.Contains(d.Id(), deviceSeparator) {
+			return importState(ctx, d, m.(*Session).withLogFields("resource", resourceType))
+		}
+		idSplit := strings.SplitN(d.Id(), deviceSeparator, 2)
+		device := idSplit[0]
+		devSess, err := m.(*Session).device(device)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(idSplit[1])
+		result, err := importState(ctx, d, devSess.withLogFields("resource", resourceType))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range result {
+			if tfErr := r.Set("device", device); tfErr != nil {
+				panic(tfErr)
+			}
+			r.SetId(device + deviceSeparator + r.Id())
+		}
+
+		return result, nil
+	}
+}
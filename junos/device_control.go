@@ -0,0 +1,166 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultMaxSessionsPerConnection : default value of 'system services ssh max-sessions-per-connection' on Junos.
+const defaultMaxSessionsPerConnection = 10
+
+var (
+	// deviceControls : concurrency control of each device (by address), shared by all providers of the process.
+	deviceControls      = make(map[string]*deviceControl)
+	deviceControlsMutex = &sync.Mutex{}
+)
+
+// deviceControl : concurrency control of the actions on a device.
+// Read-only RPCs run in parallel up to the read parallelism
+// and only one session at a time locks the candidate configuration or commits.
+type deviceControl struct {
+	limited    bool
+	readLimit  int
+	address    string
+	limitMutex sync.Mutex
+	readSlots  chan struct{}
+	commitSlot chan struct{}
+}
+
+// deviceControlFor returns the concurrency control of the device at address.
+// With several providers connected on the device, the smallest readParallelism is used.
+func deviceControlFor(address string, readParallelism int) *deviceControl {
+	deviceControlsMutex.Lock()
+	control, ok := deviceControls[address]
+	if !ok {
+		control = &deviceControl{
+			readLimit:  readParallelism,
+			address:    address,
+			readSlots:  make(chan struct{}, readParallelism),
+			commitSlot: make(chan struct{}, 1),
+		}
+		deviceControls[address] = control
+	}
+	deviceControlsMutex.Unlock()
+	if ok {
+		control.limitMutex.Lock()
+		control.reduceReadLimit(readParallelism)
+		control.limitMutex.Unlock()
+	}
+
+	return control
+}
+
+// reduceReadLimit reduces the number of read slots to limit (at least 1),
+// the slots above the limit are taken (after their release if used) and never released.
+// c.limitMutex must be locked.
+func (c *deviceControl) reduceReadLimit(limit int) {
+	if limit < 1 {
+		limit = 1
+	}
+	for ; c.readLimit > limit; c.readLimit-- {
+		c.readSlots <- struct{}{}
+	}
+}
+
+// limitReadParallelism reduces the read parallelism to the 'max-sessions-per-connection'
+// of ssh service configured on the device.
+// It's done once per device, the read of configuration is tried again on the next read if it fails.
+func (c *deviceControl) limitReadParallelism(jnpr *NetconfObject) {
+	c.limitMutex.Lock()
+	defer c.limitMutex.Unlock()
+	if c.limited {
+		return
+	}
+	maxSessions, err := readMaxSessionsPerConnection(jnpr)
+	if err != nil {
+		jnpr.log().Warn("failed to read max-sessions-per-connection, read parallelism not limited yet",
+			"error", err)
+
+		return
+	}
+	c.limited = true
+	c.reduceReadLimit(maxSessions)
+	jnpr.log().Debug("read parallelism", "limit", c.readLimit, "max_sessions_per_connection", maxSessions)
+}
+
+// readMaxSessionsPerConnection returns the 'max-sessions-per-connection' of ssh service on the device.
+func readMaxSessionsPerConnection(jnpr *NetconfObject) (int, error) {
+	read, err := jnpr.netconfCommand("show configuration system services ssh max-sessions-per-connection" +
+		" | display set relative")
+	if read == emptyWord {
+		return defaultMaxSessionsPerConnection, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	for _, item := range strings.Split(read, "\n") {
+		itemTrim := strings.TrimPrefix(item, setLineStart)
+		if itemTrim == item || itemTrim == "" {
+			continue
+		}
+		maxSessions, err := strconv.Atoi(itemTrim)
+		if err != nil {
+			return 0, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrim, err)
+		}
+
+		return maxSessions, nil
+	}
+
+	return defaultMaxSessionsPerConnection, nil
+}
+
+// acquireRead waits for a read slot on the device of sess (or the end of ctx).
+// The returned func releases the slot.
+func (sess *Session) acquireRead(ctx context.Context, jnpr *NetconfObject) (release func(), err error) {
+	if sess.control == nil {
+		return func() {}, nil
+	}
+	sess.control.limitReadParallelism(jnpr)
+	select {
+	case sess.control.readSlots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to wait a read slot on device %s : %w", sess.control.address, ctx.Err())
+	}
+
+	return func() { <-sess.control.readSlots }, nil
+}
+
+// readRPC runs the read-only rpc of action with a read slot of the device and retries it after a transport failure.
+func (sess *Session) readRPC(jnpr *NetconfObject, operation string, action func() error) error {
+	release, err := sess.acquireRead(jnpr.sessionContext(), jnpr)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return sess.retryTransport(jnpr, operation, action)
+}
+
+// acquireCommit waits for the commit slot on the device of sess (or the end of ctx)
+// and keeps it in jnpr until releaseCommit (already acquired is a no-op).
+func (sess *Session) acquireCommit(ctx context.Context, jnpr *NetconfObject) (acquired bool, err error) {
+	if sess.control == nil || jnpr.commitSlot != nil {
+		return false, nil
+	}
+	select {
+	case sess.control.commitSlot <- struct{}{}:
+	case <-ctx.Done():
+		return false, fmt.Errorf("failed to wait the end of the commit of another resource on device %s : %w",
+			sess.control.address, ctx.Err())
+	}
+	jnpr.commitSlot = sess.control.commitSlot
+
+	return true, nil
+}
+
+// releaseCommit releases the commit slot kept by jnpr.
+func (j *NetconfObject) releaseCommit() {
+	if j.commitSlot == nil {
+		return
+	}
+	<-j.commitSlot
+	j.commitSlot = nil
+}
@@ -0,0 +1,97 @@
+package junos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDeviceResourceAction(t *testing.T) {
+	sess := &Session{junosIP: "192.0.2.1"}
+	sess.devices = map[string]*Session{
+		"srx1": sess.newDeviceSession(deviceConfig{name: "srx1", ip: "192.0.2.2"}, 0, 0),
+	}
+	var gotIP, gotID string
+	res := resourcesWithDevice(map[string]*schema.Resource{
+		"junos_test": {
+			ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+				gotIP = m.(*Session).junosIP
+				gotID = d.Id()
+
+				return nil
+			},
+			Importer: &schema.ResourceImporter{
+				StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+					gotIP = m.(*Session).junosIP
+					gotID = d.Id()
+
+					return []*schema.ResourceData{d}, nil
+				},
+			},
+			Schema: map[string]*schema.Schema{},
+		},
+	})["junos_test"]
+
+	tests := map[string]struct {
+		device  string
+		id      string
+		wantIP  string
+		wantID  string
+		stateID string
+	}{
+		"without_device": {
+			id:      "name",
+			wantIP:  "192.0.2.1",
+			wantID:  "name",
+			stateID: "name",
+		},
+		"with_device": {
+			device:  "srx1",
+			id:      "srx1" + deviceSeparator + "name",
+			wantIP:  "192.0.2.2",
+			wantID:  "name",
+			stateID: "srx1" + deviceSeparator + "name",
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			d := res.Data(nil)
+			d.SetId(tt.id)
+			if err := d.Set("device", tt.device); err != nil {
+				t.Fatal(err)
+			}
+			if diags := res.ReadContext(context.Background(), d, sess); diags.HasError() {
+				t.Fatalf("read: %v", diags)
+			}
+			if gotIP != tt.wantIP || gotID != tt.wantID {
+				t.Errorf("read with ip %q and id %q, want %q and %q", gotIP, gotID, tt.wantIP, tt.wantID)
+			}
+			if d.Id() != tt.stateID {
+				t.Errorf("id after read = %q, want %q", d.Id(), tt.stateID)
+			}
+
+			d = res.Data(nil)
+			d.SetId(tt.id)
+			result, err := res.Importer.StateContext(context.Background(), d, sess)
+			if err != nil {
+				t.Fatalf("import: %s", err)
+			}
+			if gotIP != tt.wantIP || gotID != tt.wantID {
+				t.Errorf("import with ip %q and id %q, want %q and %q", gotIP, gotID, tt.wantIP, tt.wantID)
+			}
+			if result[0].Id() != tt.stateID || result[0].Get("device").(string) != tt.device {
+				t.Errorf("imported id %q and device %q, want %q and %q",
+					result[0].Id(), result[0].Get("device"), tt.stateID, tt.device)
+			}
+		})
+	}
+
+	d := res.Data(nil)
+	d.SetId("srx2" + deviceSeparator + "name")
+	if _, err := res.Importer.StateContext(context.Background(), d, sess); err == nil {
+		t.Errorf("import with unknown device: want an error")
+	}
+}
@@ -1,35 +1,19 @@
 package junos
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
 	"net"
-	"os"
 	"strings"
-	"time"
+	"sync"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
-func logFile(message string, file string) {
-	// create your file with desired read/write permissions
-	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	// defer to close when you're done with it, not because you think it's idiomatic!
-	defer f.Close()
-
-	// set output of logs to f
-	log.SetOutput(f)
-	log.SetPrefix(time.Now().Format("2006-01-02 15:04:05"))
-
-	log.Printf("%s", message)
-}
-
 func appendDiagWarns(diags *diag.Diagnostics, warns []error) {
 	for _, w := range warns {
 		*diags = append(*diags, diag.Diagnostic{
@@ -246,3 +230,128 @@ func checkStringHasPrefixInList(s string, list []string) bool {
 
 	return false
 }
+
+// customizeDiffCommitCheck runs, if commit_check_on_plan is enabled, a commit check during plan
+// with the configuration of the resource staged by stage (with planned values)
+// in a private candidate configuration.
+// The warnings of commit check are added to the diagnostics of plan (and to the error if it fails),
+// the error is attached to the attribute of the element in error when it's found.
+// If diff_on_plan is enabled, junos_diff is set with the differences of the private candidate.
+// newResource generates the resource (schema) of resourceType, only on the first commit check of resourceType.
+func customizeDiffCommitCheck(ctx context.Context, resourceType string, newResource func() *schema.Resource,
+	diff *schema.ResourceDiff, m interface{},
+	stage func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error) error {
+	sess := m.(*Session)
+	if !sess.junosCommitCheckPlan && !sess.junosDiffPlan {
+		return nil
+	}
+	if len(diff.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	res := commitCheckResource(resourceType, newResource)
+	for k := range res.Schema {
+		if !diff.NewValueKnown(k) {
+			sess.log().Warn("commit check and diff skipped, value known after apply",
+				"resource", resourceType, "attribute", k)
+
+			return nil
+		}
+	}
+	d, err := resourceDataFromDiff(res, diff)
+	if err != nil {
+		return err
+	}
+	compare, warns, err := sess.planCheck(ctx, func(jnprSess *NetconfObject) error {
+		return stage(d, m, jnprSess)
+	})
+	warnMessages := make([]string, 0, len(warns))
+	for _, w := range warns {
+		sess.log().Warn("commit check warning", "resource", resourceType, "warning", w)
+		warnMessages = append(warnMessages, w.Error())
+		if err == nil {
+			addPlanWarning(ctx, "commit check warning on "+resourceType, w.Error())
+		}
+	}
+	if err != nil {
+		action := "commit check"
+		if !sess.junosCommitCheckPlan {
+			action = "diff"
+		}
+		if len(warnMessages) > 0 {
+			err = fmt.Errorf("%w\nWarnings: %s", err, strings.Join(warnMessages, ", "))
+		}
+		err = fmt.Errorf("%s of %s failed : %w", action, resourceType, err)
+		if attribute, ok := commitErrorAttribute(res, err); ok {
+			return cty.GetAttrPath(attribute).NewError(err)
+		}
+
+		return err
+	}
+	if sess.junosDiffPlan {
+		sess.log().Info("diff of planned configuration", "resource", resourceType, "diff", compare)
+
+		return diff.SetNew("junos_diff", compare)
+	}
+
+	return nil
+}
+
+// commitCheckResources : resources (schemas) of commit check on plan by resource type.
+var commitCheckResources sync.Map
+
+// commitCheckResource returns the resource of resourceType generated once with newResource.
+func commitCheckResource(resourceType string, newResource func() *schema.Resource) *schema.Resource {
+	if res, ok := commitCheckResources.Load(resourceType); ok {
+		return res.(*schema.Resource)
+	}
+	res, _ := commitCheckResources.LoadOrStore(resourceType, newResource())
+
+	return res.(*schema.Resource)
+}
+
+// commitErrorAttribute returns the argument of res matching the element in error of a commit
+// (bad element then words of configuration path from the end, with '-' replaced by '_').
+func commitErrorAttribute(res *schema.Resource, err error) (string, bool) {
+	var commitErr *commitRPCError
+	if !errors.As(err, &commitErr) {
+		return "", false
+	}
+	pathWords := strings.Fields(strings.TrimPrefix(commitErr.path, "edit "))
+	words := strings.Fields(commitErr.element)
+	for i := len(pathWords) - 1; i >= 0; i-- {
+		words = append(words, pathWords[i])
+	}
+	for _, w := range words {
+		attribute := strings.ReplaceAll(w, "-", "_")
+		if s, ok := res.Schema[attribute]; ok && (s.Optional || s.Required) {
+			return attribute, true
+		}
+	}
+
+	return "", false
+}
+
+// resourceDataFromDiff generates a ResourceData with prior state and planned values of diff
+// to use the same functions as apply to generate configuration lines.
+func resourceDataFromDiff(res *schema.Resource, diff *schema.ResourceDiff) (*schema.ResourceData, error) {
+	var state *terraform.InstanceState
+	if diff.Id() != "" {
+		prior := res.Data(nil)
+		prior.SetId(diff.Id())
+		for k := range res.Schema {
+			o, _ := diff.GetChange(k)
+			if err := prior.Set(k, o); err != nil {
+				return nil, fmt.Errorf("failed to set prior value of %s : %w", k, err)
+			}
+		}
+		state = prior.State()
+	}
+	d := res.Data(state)
+	for k := range res.Schema {
+		if err := d.Set(k, diff.Get(k)); err != nil {
+			return nil, fmt.Errorf("failed to set planned value of %s : %w", k, err)
+		}
+	}
+
+	return d, nil
+}
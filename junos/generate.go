@@ -0,0 +1,494 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// generateMatcher : find the import id of a resource type in the words of a set line
+// (without set and with quoted words kept quoted).
+type generateMatcher struct {
+	resourceType string
+	match        func(words []string) (id string, ok bool)
+}
+
+// generateMatchers : resource types supported by GenerateConfig, in order of output.
+var generateMatchers = []generateMatcher{
+	generateOneWord("junos_system", "system"),
+	generateOneWord("junos_system_root_authentication", "system", "root-authentication"),
+	generateWithName("junos_system_login_class", "system", "login", "class"),
+	generateWithName("junos_system_login_user", "system", "login", "user"),
+	generateWithName("junos_system_ntp_server", "system", "ntp", "server"),
+	generateWithName("junos_system_radius_server", "system", "radius-server"),
+	generateWithName("junos_system_syslog_file", "system", "syslog", "file"),
+	generateWithName("junos_system_syslog_host", "system", "syslog", "host"),
+	{"junos_interface_physical", generateInterfacePhysical},
+	{"junos_interface_logical", generateInterfaceLogical},
+	generateWithName("junos_vlan", "vlans"),
+	generateWithName("junos_routing_instance", "routing-instances"),
+	generateOneWord("junos_routing_options", "routing-options"),
+	generateWithName("junos_rib_group", "routing-options", "rib-groups"),
+	{"junos_static_route", generateRoute("static")},
+	{"junos_aggregate_route", generateRoute("aggregate")},
+	{"junos_bgp_group", generateBgpGroup},
+	{"junos_bgp_neighbor", generateBgpNeighbor},
+	{"junos_ospf_area", generateOspfArea},
+	generateWithName("junos_policyoptions_as_path", "policy-options", "as-path"),
+	generateWithName("junos_policyoptions_as_path_group", "policy-options", "as-path-group"),
+	generateWithName("junos_policyoptions_community", "policy-options", "community"),
+	generateWithName("junos_policyoptions_prefix_list", "policy-options", "prefix-list"),
+	generateWithName("junos_policyoptions_policy_statement", "policy-options", "policy-statement"),
+	{"junos_firewall_filter", generateFirewallFilter},
+	generateWithName("junos_firewall_policer", "firewall", "policer"),
+	generateWithName("junos_application", "applications", "application"),
+	generateWithName("junos_application_set", "applications", "application-set"),
+	generateOneWord("junos_security", "security"),
+	generateWithName("junos_security_zone", "security", "zones", "security-zone"),
+	{"junos_security_policy", generateSecurityPolicy},
+	{"junos_security_policy_tunnel_pair_policy", generateSecurityPolicyTunnelPairPolicy},
+	generateWithName("junos_security_screen", "security", "screen", "ids-option"),
+	generateWithName("junos_security_screen_whitelist", "security", "screen", "white-list"),
+	generateWithName("junos_security_log_stream", "security", "log", "stream"),
+	generateWithName("junos_security_ike_proposal", "security", "ike", "proposal"),
+	generateWithName("junos_security_ike_policy", "security", "ike", "policy"),
+	generateWithName("junos_security_ike_gateway", "security", "ike", "gateway"),
+	generateWithName("junos_security_ipsec_proposal", "security", "ipsec", "proposal"),
+	generateWithName("junos_security_ipsec_policy", "security", "ipsec", "policy"),
+	generateWithName("junos_security_ipsec_vpn", "security", "ipsec", "vpn"),
+	generateWithName("junos_security_nat_source", "security", "nat", "source", "rule-set"),
+	generateWithName("junos_security_nat_source_pool", "security", "nat", "source", "pool"),
+	generateWithName("junos_security_nat_destination", "security", "nat", "destination", "rule-set"),
+	generateWithName("junos_security_nat_destination_pool", "security", "nat", "destination", "pool"),
+	generateWithName("junos_security_nat_static", "security", "nat", "static", "rule-set"),
+	generateWithName("junos_security_utm_custom_url_category",
+		"security", "utm", "custom-objects", "custom-url-category"),
+	generateWithName("junos_security_utm_custom_url_pattern", "security", "utm", "custom-objects", "url-pattern"),
+	generateWithName("junos_security_utm_policy", "security", "utm", "utm-policy"),
+	generateWithName("junos_security_utm_profile_web_filtering_juniper_enhanced",
+		"security", "utm", "feature-profile", "web-filtering", "juniper-enhanced", "profile"),
+	generateWithName("junos_security_utm_profile_web_filtering_juniper_local",
+		"security", "utm", "feature-profile", "web-filtering", "juniper-local", "profile"),
+	generateWithName("junos_security_utm_profile_web_filtering_websense_redirect",
+		"security", "utm", "feature-profile", "web-filtering", "websense-redirect", "profile"),
+}
+
+// GenerateConfig connects to the Junos device with the provider configuration
+// from environment variables (JUNOS_HOST, JUNOS_USERNAME, ...), reads the configuration
+// and writes, for each resource found, a resource block (HCL) and an import block.
+// If resourceTypes is not empty, only these resource types are generated.
+func GenerateConfig(ctx context.Context, resourceTypes []string, w io.Writer) error {
+	for _, resourceType := range resourceTypes {
+		if !generateSupported(resourceType) {
+			return fmt.Errorf("resource type %q not supported", resourceType)
+		}
+	}
+	p := Provider()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		return diagsToError(diags)
+	}
+
+	return generateConfig(ctx, p.Meta().(*Session), p.ResourcesMap, resourceTypes, w)
+}
+
+func generateConfig(ctx context.Context, sess *Session, resources map[string]*schema.Resource,
+	resourceTypes []string, w io.Writer) error {
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return err
+	}
+	config, err := sess.command("show configuration | display set", jnprSess)
+	sess.closeSession(jnprSess)
+	if err != nil {
+		return err
+	}
+	ids := generateFindIDs(config, resourceTypes)
+	labels := make(map[string]bool)
+	for _, matcher := range generateMatchers {
+		for _, id := range ids[matcher.resourceType] {
+			res := resources[matcher.resourceType]
+			d := res.Data(nil)
+			d.SetId(id)
+			result, err := res.Importer.StateContext(ctx, d, sess)
+			if err != nil {
+				if _, err := fmt.Fprintf(w, "# %s with id %q not generated: %s\n\n",
+					matcher.resourceType, id, strings.ReplaceAll(err.Error(), "\n", " ")); err != nil {
+					return err
+				}
+
+				continue
+			}
+			var body strings.Builder
+			generateHCLBody(&body, res.Schema, func(k string) interface{} { return result[0].Get(k) }, "  ")
+			if body.Len() == 0 {
+				continue
+			}
+			label := generateLabel(id, labels)
+			if _, err := fmt.Fprintf(w, "import {\n  to = %s.%s\n  id = %s\n}\n\nresource %q %q {\n%s}\n\n",
+				matcher.resourceType, label, generateHCLString(result[0].Id()),
+				matcher.resourceType, label, body.String()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func generateSupported(resourceType string) bool {
+	for _, matcher := range generateMatchers {
+		if matcher.resourceType == resourceType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generateFindIDs returns the import ids (in order of first appearance) by resource type
+// found in the output of 'show configuration | display set'.
+func generateFindIDs(config string, resourceTypes []string) map[string][]string {
+	ids := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(config, "\n") {
+		if !strings.HasPrefix(item, setLineStart) {
+			continue
+		}
+		words := splitConfigWords(strings.TrimPrefix(item, setLineStart))
+		for _, matcher := range generateMatchers {
+			if len(resourceTypes) > 0 && !stringInSlice(matcher.resourceType, resourceTypes) {
+				continue
+			}
+			id, ok := matcher.match(words)
+			if !ok || seen[matcher.resourceType+" "+id] {
+				continue
+			}
+			seen[matcher.resourceType+" "+id] = true
+			ids[matcher.resourceType] = append(ids[matcher.resourceType], id)
+		}
+	}
+
+	return ids
+}
+
+// generateMatchWords returns the words of line matching the '*' in pattern (without quotes),
+// ok is false if line doesn't start with pattern.
+func generateMatchWords(words []string, pattern ...string) (values []string, ok bool) {
+	if len(words) < len(pattern) {
+		return nil, false
+	}
+	for i, p := range pattern {
+		if p == "*" {
+			values = append(values, strings.Trim(words[i], "\""))
+		} else if words[i] != p {
+			return nil, false
+		}
+	}
+
+	return values, true
+}
+
+// generateRoutingInstance returns the routing instance of words and words without the routing instance prefix.
+func generateRoutingInstance(words []string) (string, []string) {
+	if values, ok := generateMatchWords(words, "routing-instances", "*"); ok {
+		return values[0], words[2:]
+	}
+
+	return defaultWord, words
+}
+
+// generateOneWord : resource without name (id is the resource type without junos_ prefix).
+func generateOneWord(resourceType string, path ...string) generateMatcher {
+	return generateMatcher{
+		resourceType: resourceType,
+		match: func(words []string) (string, bool) {
+			if _, ok := generateMatchWords(words, path...); !ok {
+				return "", false
+			}
+
+			return strings.TrimPrefix(resourceType, "junos_"), true
+		},
+	}
+}
+
+// generateWithName : resource with the name as id after path.
+func generateWithName(resourceType string, path ...string) generateMatcher {
+	pattern := append(append(make([]string, 0, len(path)+1), path...), "*")
+
+	return generateMatcher{
+		resourceType: resourceType,
+		match: func(words []string) (string, bool) {
+			values, ok := generateMatchWords(words, pattern...)
+			if !ok {
+				return "", false
+			}
+
+			return values[0], true
+		},
+	}
+}
+
+func generateInterfacePhysical(words []string) (string, bool) {
+	values, ok := generateMatchWords(words, "interfaces", "*", "*")
+	if !ok || values[0] == "interface-range" || values[1] == "unit" || strings.Contains(values[0], ".") {
+		return "", false
+	}
+
+	return values[0], true
+}
+
+func generateInterfaceLogical(words []string) (string, bool) {
+	values, ok := generateMatchWords(words, "interfaces", "*", "unit", "*")
+	if !ok || values[0] == "interface-range" {
+		return "", false
+	}
+
+	return values[0] + "." + values[1], true
+}
+
+// generateRoute : static or aggregate route (inet and inet6) with id <destination>_-_<routing_instance>.
+func generateRoute(routeType string) func(words []string) (string, bool) {
+	return func(words []string) (string, bool) {
+		instance, words := generateRoutingInstance(words)
+		if values, ok := generateMatchWords(words, "routing-options", routeType, "route", "*"); ok {
+			return values[0] + idSeparator + instance, true
+		}
+		if values, ok := generateMatchWords(words, "routing-options", "rib", "*", routeType, "route", "*"); ok &&
+			strings.HasSuffix(values[0], "inet6.0") {
+			return values[1] + idSeparator + instance, true
+		}
+
+		return "", false
+	}
+}
+
+func generateBgpGroup(words []string) (string, bool) {
+	instance, words := generateRoutingInstance(words)
+	values, ok := generateMatchWords(words, "protocols", "bgp", "group", "*")
+	if !ok {
+		return "", false
+	}
+
+	return values[0] + idSeparator + instance, true
+}
+
+func generateBgpNeighbor(words []string) (string, bool) {
+	instance, words := generateRoutingInstance(words)
+	values, ok := generateMatchWords(words, "protocols", "bgp", "group", "*", "neighbor", "*")
+	if !ok {
+		return "", false
+	}
+
+	return values[1] + idSeparator + instance + idSeparator + values[0], true
+}
+
+func generateOspfArea(words []string) (string, bool) {
+	instance, words := generateRoutingInstance(words)
+	if values, ok := generateMatchWords(words, "protocols", opsfV2, "area", "*"); ok {
+		return values[0] + idSeparator + "v2" + idSeparator + instance, true
+	}
+	if values, ok := generateMatchWords(words, "protocols", ospfV3, "area", "*"); ok {
+		return values[0] + idSeparator + "v3" + idSeparator + instance, true
+	}
+
+	return "", false
+}
+
+func generateFirewallFilter(words []string) (string, bool) {
+	values, ok := generateMatchWords(words, "firewall", "family", "*", "filter", "*")
+	if !ok {
+		return "", false
+	}
+
+	return values[1] + idSeparator + values[0], true
+}
+
+func generateSecurityPolicy(words []string) (string, bool) {
+	values, ok := generateMatchWords(words, "security", "policies", "from-zone", "*", "to-zone", "*")
+	if !ok {
+		return "", false
+	}
+
+	return values[0] + idSeparator + values[1], true
+}
+
+// generateSecurityPolicyTunnelPairPolicy returns the id only for one of the two policies of the pair.
+func generateSecurityPolicyTunnelPairPolicy(words []string) (string, bool) {
+	values, ok := generateMatchWords(words, "security", "policies", "from-zone", "*", "to-zone", "*",
+		"policy", "*", thenWord, permitWord, "tunnel", "pair-policy", "*")
+	if !ok {
+		return "", false
+	}
+	zoneA, zoneB, policyAtoB, policyBtoA := values[0], values[1], values[2], values[3]
+	if zoneA > zoneB || (zoneA == zoneB && policyAtoB > policyBtoA) {
+		return "", false
+	}
+
+	return zoneA + idSeparator + policyAtoB + idSeparator + zoneB + idSeparator + policyBtoA, true
+}
+
+// generateLabel returns a unique name of resource in HCL from the id.
+func generateLabel(id string, labels map[string]bool) string {
+	var label strings.Builder
+	for _, r := range strings.ToLower(id) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			label.WriteRune(r)
+		} else {
+			label.WriteRune('_')
+		}
+	}
+	base := label.String()
+	if base == "" || (base[0] >= '0' && base[0] <= '9') || base[0] == '-' {
+		base = "_" + base
+	}
+	name := base
+	for i := 2; labels[name]; i++ {
+		name = base + "_" + strconv.Itoa(i)
+	}
+	labels[name] = true
+
+	return name
+}
+
+// generateHCLBody writes arguments (required or with a value not empty and not the default)
+// and blocks of schema in body.
+func generateHCLBody(body *strings.Builder, schemaMap map[string]*schema.Schema,
+	get func(k string) interface{}, indent string) {
+	attributes := make([]string, 0)
+	blocks := make([]string, 0)
+	for k, s := range schemaMap {
+		if (!s.Required && !s.Optional) || s.Deprecated != "" {
+			continue
+		}
+		if _, ok := s.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+		} else {
+			attributes = append(attributes, k)
+		}
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		if schemaMap[attributes[i]].Required != schemaMap[attributes[j]].Required {
+			return schemaMap[attributes[i]].Required
+		}
+
+		return attributes[i] < attributes[j]
+	})
+	sort.Strings(blocks)
+	lines := make([][2]string, 0, len(attributes))
+	keyLen := 0
+	for _, k := range attributes {
+		value, empty := generateHCLValue(get(k))
+		if !schemaMap[k].Required && (empty || reflect.DeepEqual(get(k), schemaMap[k].Default)) {
+			continue
+		}
+		lines = append(lines, [2]string{k, value})
+		if len(k) > keyLen {
+			keyLen = len(k)
+		}
+	}
+	for _, line := range lines {
+		body.WriteString(fmt.Sprintf("%s%-*s = %s\n", indent, keyLen, line[0], line[1]))
+	}
+	for _, k := range blocks {
+		elem := schemaMap[k].Elem.(*schema.Resource)
+		for _, v := range generateList(get(k)) {
+			values, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var blockBody strings.Builder
+			generateHCLBody(&blockBody, elem.Schema, func(k string) interface{} { return values[k] }, indent+"  ")
+			body.WriteString(indent + k + " {\n" + blockBody.String() + indent + "}\n")
+		}
+	}
+}
+
+// generateList returns the elements of a list or a set.
+func generateList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	}
+
+	return nil
+}
+
+// generateHCLValue returns the HCL expression of value and if value is empty.
+func generateHCLValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return generateHCLString(v), v == ""
+	case int:
+		return strconv.Itoa(v), v == 0
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), v == 0
+	case bool:
+		return strconv.FormatBool(v), !v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		elems := make([]string, 0, len(keys))
+		for _, k := range keys {
+			elem, _ := generateHCLValue(v[k])
+			elems = append(elems, generateHCLString(k)+" = "+elem)
+		}
+
+		return "{ " + strings.Join(elems, ", ") + " }", len(v) == 0
+	case nil:
+		return "null", true
+	}
+	list := generateList(value)
+	elems := make([]string, 0, len(list))
+	for _, e := range list {
+		elem, _ := generateHCLValue(e)
+		elems = append(elems, elem)
+	}
+
+	return "[" + strings.Join(elems, ", ") + "]", len(list) == 0
+}
+
+// generateHCLString returns a quoted HCL string (without template interpolation).
+func generateHCLString(s string) string {
+	s = strings.NewReplacer(
+		"\\", "\\\\",
+		"\"", "\\\"",
+		"\n", "\\n",
+		"\r", "\\r",
+		"\t", "\\t",
+		"${", "$${",
+		"%{", "%%{",
+	).Replace(s)
+
+	return "\"" + s + "\""
+}
+
+// diagsToError returns an error with the summary and details of error diagnostics.
+func diagsToError(diags diag.Diagnostics) error {
+	messages := make([]string, 0)
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		if d.Detail != "" {
+			messages = append(messages, d.Summary+": "+d.Detail)
+		} else {
+			messages = append(messages, d.Summary)
+		}
+	}
+
+	return errors.New(strings.Join(messages, ", "))
+}
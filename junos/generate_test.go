@@ -0,0 +1,79 @@
+package junos
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateFindIDs(t *testing.T) {
+	config := strings.Join([]string{
+		"<configuration-output>",
+		"set system host-name srx1",
+		"set system login user admin class super-user",
+		"set interfaces ge-0/0/0 description WAN",
+		"set interfaces ge-0/0/0 unit 0 family inet address 192.0.2.1/24",
+		"set interfaces ge-0/0/1 unit 0 family inet",
+		"set routing-options static route 0.0.0.0/0 next-hop 192.0.2.254",
+		"set routing-options rib inet6.0 static route ::/0 next-hop 2001:db8::1",
+		"set routing-instances RI1 routing-options static route 198.51.100.0/24 discard",
+		"set routing-instances RI1 protocols bgp group EBGP neighbor 192.0.2.2 peer-as 65001",
+		"set protocols ospf3 area 0.0.0.0 interface ge-0/0/0.0",
+		"set firewall family inet filter FILTER1 term T1 then accept",
+		"set security policies from-zone trust to-zone untrust policy P1 then permit tunnel pair-policy P2",
+		"set security policies from-zone untrust to-zone trust policy P2 then permit tunnel pair-policy P1",
+		"set security log stream \"stream 1\" host 192.0.2.10",
+		"</configuration-output>",
+	}, "\n")
+	ids := generateFindIDs(config, nil)
+	want := map[string][]string{
+		"junos_system":                             {"system"},
+		"junos_system_login_user":                  {"admin"},
+		"junos_interface_physical":                 {"ge-0/0/0"},
+		"junos_interface_logical":                  {"ge-0/0/0.0", "ge-0/0/1.0"},
+		"junos_routing_instance":                   {"RI1"},
+		"junos_routing_options":                    {"routing_options"},
+		"junos_static_route":                       {"0.0.0.0/0_-_default", "::/0_-_default", "198.51.100.0/24_-_RI1"},
+		"junos_bgp_group":                          {"EBGP_-_RI1"},
+		"junos_bgp_neighbor":                       {"192.0.2.2_-_RI1_-_EBGP"},
+		"junos_ospf_area":                          {"0.0.0.0_-_v3_-_default"},
+		"junos_firewall_filter":                    {"FILTER1_-_inet"},
+		"junos_security":                           {"security"},
+		"junos_security_policy":                    {"trust_-_untrust", "untrust_-_trust"},
+		"junos_security_policy_tunnel_pair_policy": {"trust_-_P1_-_untrust_-_P2"},
+		"junos_security_log_stream":                {"stream 1"},
+	}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("generateFindIDs = %v, want %v", ids, want)
+	}
+
+	ids = generateFindIDs(config, []string{"junos_static_route"})
+	if len(ids) != 1 || len(ids["junos_static_route"]) != 3 {
+		t.Errorf("generateFindIDs with resource type filter = %v, want only static routes", ids)
+	}
+}
+
+func TestGenerateLabel(t *testing.T) {
+	labels := make(map[string]bool)
+	for _, tt := range []struct {
+		id   string
+		want string
+	}{
+		{"192.0.2.0/24_-_default", "_192_0_2_0_24_-_default"},
+		{"ge-0/0/0.0", "ge-0_0_0_0"},
+		{"ge-0/0/0_0", "ge-0_0_0_0_2"},
+		{"stream 1", "stream_1"},
+	} {
+		if got := generateLabel(tt.id, labels); got != tt.want {
+			t.Errorf("generateLabel(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateHCLString(t *testing.T) {
+	got := generateHCLString("a \"quoted\" ${var} %{if}\\")
+	want := `"a \"quoted\" $${var} %%{if}\\"`
+	if got != want {
+		t.Errorf("generateHCLString = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package netconftest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// configOp : a set or delete line loaded in a candidate configuration.
+type configOp struct {
+	delete bool
+	words  []string
+}
+
+// splitWords splits a configuration line in words, a quoted string is one word (with the quotes).
+func splitWords(line string) []string {
+	words := make([]string, 0)
+	var word strings.Builder
+	quoted := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			word.WriteRune(r)
+		case (r == ' ' || r == '\t' || r == '\r') && !quoted:
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+		default:
+			word.WriteRune(r)
+		}
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+
+	return words
+}
+
+// hasPrefixWords returns true if words starts with all words of prefix.
+func hasPrefixWords(words, prefix []string) bool {
+	if len(prefix) > len(words) {
+		return false
+	}
+	for i, w := range prefix {
+		if words[i] != w {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseConfigSet parses the lines of a load-configuration with action set.
+func parseConfigSet(text string) ([]configOp, error) {
+	ops := make([]configOp, 0)
+	for _, line := range strings.Split(text, "\n") {
+		words := splitWords(line)
+		if len(words) == 0 {
+			continue
+		}
+		switch words[0] {
+		case "set":
+			if len(words) == 1 {
+				return nil, fmt.Errorf("syntax error: %s", line)
+			}
+			ops = append(ops, configOp{words: words[1:]})
+		case "delete":
+			if len(words) == 1 {
+				return nil, fmt.Errorf("syntax error: %s", line)
+			}
+			ops = append(ops, configOp{delete: true, words: words[1:]})
+		default:
+			return nil, fmt.Errorf("syntax error, expecting 'set' or 'delete': %s", line)
+		}
+	}
+
+	return ops, nil
+}
+
+// applyOps returns a copy of config with ops applied.
+// A set adds the line if it isn't already present,
+// a delete removes the line and all lines under it.
+func applyOps(config [][]string, ops []configOp) [][]string {
+	result := make([][]string, len(config))
+	copy(result, config)
+	for _, op := range ops {
+		if op.delete {
+			kept := make([][]string, 0, len(result))
+			for _, words := range result {
+				if !hasPrefixWords(words, op.words) {
+					kept = append(kept, words)
+				}
+			}
+			result = kept
+
+			continue
+		}
+		found := false
+		for _, words := range result {
+			if len(words) == len(op.words) && hasPrefixWords(words, op.words) {
+				found = true
+
+				break
+			}
+		}
+		if !found {
+			result = append(result, op.words)
+		}
+	}
+
+	return result
+}
+
+// displaySet renders config under path like 'show configuration <path> | display set [relative]'.
+// A line with other lines under it isn't displayed (like the containers on a Junos device).
+// found is false if there is no configuration under path.
+func displaySet(config [][]string, path []string, relative bool) (output []string, found bool) {
+	output = make([]string, 0)
+	for i, words := range config {
+		if !hasPrefixWords(words, path) {
+			continue
+		}
+		found = true
+		container := false
+		for j, other := range config {
+			if i != j && len(other) > len(words) && hasPrefixWords(other, words) {
+				container = true
+
+				break
+			}
+		}
+		if container {
+			continue
+		}
+		if relative {
+			if len(words) > len(path) {
+				output = append(output, "set "+strings.Join(words[len(path):], " "))
+			}
+
+			continue
+		}
+		output = append(output, "set "+strings.Join(words, " "))
+	}
+
+	return output, found
+}
+
+// compareConfig renders the differences between config and candidate like 'show | compare'
+// but with set lines (prefixed with '-' for removed lines and '+' for added lines).
+func compareConfig(config, candidate [][]string) []string {
+	output := make([]string, 0)
+	oldLines, _ := displaySet(config, nil, false)
+	newLines, _ := displaySet(candidate, nil, false)
+	for _, line := range oldLines {
+		if !stringInSlice(line, newLines) {
+			output = append(output, "- "+line)
+		}
+	}
+	for _, line := range newLines {
+		if !stringInSlice(line, oldLines) {
+			output = append(output, "+ "+line)
+		}
+	}
+	if len(output) > 0 {
+		output = append([]string{"[edit]"}, output...)
+	}
+
+	return output
+}
@@ -0,0 +1,172 @@
+package netconftest
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// The XML rendering of configuration only knows the elements of the Junos XML schema
+// which differ from the words of set lines and are read by the provider with get-configuration.
+var (
+	// xmlKeyedLists : lists with the entry identified by the next word in a name element.
+	xmlKeyedLists = map[string]bool{
+		"groups":             true,
+		"logical-systems":    true,
+		"tenants":            true,
+		"rib":                true,
+		"route":              true,
+		"qualified-next-hop": true,
+	}
+	// xmlListEntries : lists with entries in a child element (and the next word in a name element).
+	xmlListEntries = map[string]string{
+		"routing-instances": "instance",
+	}
+	// xmlMetricValues : leaves with the value in a metric-value element under a parent element.
+	xmlMetricValues = map[string]string{
+		"preference": "route",
+		"metric":     "route",
+	}
+)
+
+// xmlNode : element of configuration or of a subtree filter.
+type xmlNode struct {
+	XMLName  xml.Name
+	Text     string    `xml:",chardata"`
+	Children []xmlNode `xml:",any"`
+}
+
+// child returns the container (or list entry with key) child of node, created if it doesn't exist.
+func (node *xmlNode) child(name, key string) *xmlNode {
+	for i := range node.Children {
+		c := &node.Children[i]
+		if c.XMLName.Local == name && c.key() == key && (key != "" || len(c.Children) > 0 || c.Text == "") {
+			return c
+		}
+	}
+	c := xmlNode{XMLName: xml.Name{Local: name}}
+	if key != "" {
+		c.Children = []xmlNode{{XMLName: xml.Name{Local: "name"}, Text: key}}
+	}
+	node.Children = append(node.Children, c)
+
+	return &node.Children[len(node.Children)-1]
+}
+
+// key returns the text of the name child of node.
+func (node *xmlNode) key() string {
+	for _, c := range node.Children {
+		if c.XMLName.Local == "name" {
+			return strings.TrimSpace(c.Text)
+		}
+	}
+
+	return ""
+}
+
+// configTree returns the XML tree of config (set lines without 'set').
+func configTree(config [][]string) xmlNode {
+	root := xmlNode{XMLName: xml.Name{Local: "configuration"}}
+	for _, words := range config {
+		node := &root
+		for i := 0; i < len(words); i++ {
+			word := words[i]
+			switch {
+			case xmlListEntries[word] != "" && i+1 < len(words):
+				node = node.child(word, "").child(xmlListEntries[word], unquote(words[i+1]))
+				i++
+			case xmlKeyedLists[word] && i+1 < len(words):
+				node = node.child(word, unquote(words[i+1]))
+				i++
+			case i == len(words)-1:
+				node.child(word, "")
+			case i == len(words)-2:
+				leaf := xmlNode{XMLName: xml.Name{Local: word}, Text: unquote(words[i+1])}
+				if parent, ok := xmlMetricValues[word]; ok && parent == node.XMLName.Local {
+					leaf = xmlNode{XMLName: xml.Name{Local: word}, Children: []xmlNode{
+						{XMLName: xml.Name{Local: "metric-value"}, Text: leaf.Text},
+					}}
+				}
+				node.Children = append(node.Children, leaf)
+				i++
+			default:
+				node = node.child(word, "")
+			}
+		}
+	}
+
+	return root
+}
+
+// filterTree returns the children of node selected by the children of filter (subtree filter),
+// a filter element without children selects all the element,
+// a filter element with only a name child selects the list entry with this name.
+func filterTree(node, filter xmlNode) []xmlNode {
+	result := make([]xmlNode, 0)
+	for _, f := range filter.Children {
+		for _, c := range node.Children {
+			if c.XMLName.Local != f.XMLName.Local {
+				continue
+			}
+			if key := f.key(); key != "" && c.key() != key {
+				continue
+			}
+			selectors := make([]xmlNode, 0, len(f.Children))
+			for _, fc := range f.Children {
+				if fc.XMLName.Local != "name" {
+					selectors = append(selectors, fc)
+				}
+			}
+			if len(selectors) == 0 {
+				result = append(result, c)
+
+				continue
+			}
+			f.Children = selectors
+			children := filterTree(c, f)
+			if len(children) == 0 {
+				continue
+			}
+			selected := xmlNode{XMLName: c.XMLName}
+			if key := c.key(); key != "" {
+				selected.Children = append(selected.Children, xmlNode{XMLName: xml.Name{Local: "name"}, Text: key})
+			}
+			selected.Children = append(selected.Children, children...)
+			result = append(result, selected)
+		}
+	}
+
+	return result
+}
+
+// configXML renders config under the subtree filter (content of configuration element of get-configuration)
+// like the configuration element of the reply of get-configuration.
+func configXML(config [][]string, filter string) (string, error) {
+	tree := configTree(config)
+	if strings.TrimSpace(filter) != "" {
+		var filterNode xmlNode
+		if err := xml.Unmarshal([]byte("<configuration>"+filter+"</configuration>"), &filterNode); err != nil {
+			return "", err
+		}
+		tree.Children = filterTree(tree, filterNode)
+	}
+	output, err := xml.Marshal(tree)
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+// unquote removes the quotes of a quoted word.
+func unquote(word string) string {
+	if len(word) >= 2 && strings.HasPrefix(word, `"`) && strings.HasSuffix(word, `"`) {
+		if s, err := strconv.Unquote(word); err == nil {
+			return s
+		}
+
+		return word[1 : len(word)-1]
+	}
+
+	return word
+}
@@ -0,0 +1,731 @@
+// Package netconftest provides a local ssh netconf server which acts like a Junos device
+// to run tests without hardware.
+//
+// The server holds the configuration as set lines, applies load-configuration with action set,
+// renders 'show configuration ... | display set [relative]', the configuration in XML
+// (get-configuration with a subtree filter), the differences of the candidate
+// (get-configuration with compare) and implements lock, unlock,
+// open-configuration private, close-configuration, commit (with 'show system commit'),
+// commit confirmed (rolled back with ExpireCommitConfirmed) and delete-config.
+package netconftest
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const msgSeparator = "]]>]]>"
+
+// SystemInformation : reply of get-system-information.
+type SystemInformation struct {
+	HardwareModel string `xml:"hardware-model"`
+	OsName        string `xml:"os-name"`
+	OsVersion     string `xml:"os-version"`
+	SerialNumber  string `xml:"serial-number"`
+	HostName      string `xml:"host-name"`
+}
+
+// Server : local ssh netconf server.
+type Server struct {
+	// Addr : listening address (host:port).
+	Addr string
+	// Username and Password accepted for password authentication.
+	Username string
+	Password string
+	// SystemInformation returned by get-system-information.
+	SystemInformation SystemInformation
+	// Interfaces : physical interfaces available on the device.
+	Interfaces []string
+	// CommitHook, if not nil, is called with the configuration (set lines) to commit or check.
+	// A returned error is sent as an error of the commit (with its path and element if it's a *CommitError,
+	// several errors if it's a CommitErrors).
+	CommitHook func(config []string) error
+	// RPCHook, if not nil, is called with the name of each rpc before handling it
+	// (to delay the reply for example) and its result can simulate a transport failure.
+	RPCHook func(method string) HookAction
+
+	mutex         sync.Mutex
+	nextSessionID int
+	lockSessionID int
+	running       [][]string
+	sharedOps     []configOp
+	commits       int
+	commitHistory []commitEntry
+	rollback      [][]string
+	listener      net.Listener
+	hostKey       ssh.Signer
+	wg            sync.WaitGroup
+}
+
+// CommitError : error of CommitHook with the configuration path and the element in error,
+// with Warning the error is sent as a warning and the commit continues.
+type CommitError struct {
+	Warning bool
+	Path    string
+	Element string
+	Message string
+}
+
+func (e *CommitError) Error() string {
+	return e.Message
+}
+
+// CommitErrors : errors of CommitHook sent in order (warnings before an error for example).
+type CommitErrors []*CommitError
+
+func (e CommitErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// commitEntry : commit in the history displayed by 'show system commit'.
+type commitEntry struct {
+	time time.Time
+	log  string
+}
+
+// HookAction : action of the server after RPCHook.
+type HookAction int
+
+const (
+	// Continue handles the rpc and replies.
+	Continue HookAction = iota
+	// DropBefore closes the netconf session without handling the rpc.
+	DropBefore
+	// DropAfter handles the rpc then closes the netconf session without reply.
+	DropAfter
+)
+
+// session : state of a netconf session.
+type session struct {
+	private bool
+	id      int
+	ops     []configOp
+}
+
+// NewServer starts a server listening on a random port on 127.0.0.1.
+func NewServer(username, password string) (*Server, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	hostKey, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		Addr:     listener.Addr().String(),
+		Username: username,
+		Password: password,
+		SystemInformation: SystemInformation{
+			HardwareModel: "vsrx",
+			OsName:        "junos",
+			OsVersion:     "20.2R1.10",
+			SerialNumber:  "0000000000",
+			HostName:      "netconftest",
+		},
+		nextSessionID: 1000,
+		listener:      listener,
+		hostKey:       hostKey,
+	}
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// HostKeyFingerprint returns the SHA256 fingerprint of the ssh host key of the server.
+func (s *Server) HostKeyFingerprint() string {
+	return ssh.FingerprintSHA256(s.hostKey.PublicKey())
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+
+	return err
+}
+
+// Running returns the committed configuration as set lines.
+func (s *Server) Running() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	lines := make([]string, 0, len(s.running))
+	for _, words := range s.running {
+		lines = append(lines, "set "+strings.Join(words, " "))
+	}
+
+	return lines
+}
+
+// SetRunning replaces the committed configuration with set lines.
+func (s *Server) SetRunning(lines []string) error {
+	ops, err := parseConfigSet(strings.Join(lines, "\n"))
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.running = applyOps(nil, ops)
+
+	return nil
+}
+
+// CommitConfirmedPending returns true if a commit confirmed waits a confirmation
+// (the running configuration before the commit confirmed is kept to roll back).
+func (s *Server) CommitConfirmedPending() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.rollback != nil
+}
+
+// ExpireCommitConfirmed acts like the end of the timeout of a commit confirmed not confirmed:
+// the running configuration is rolled back to the configuration before the commit confirmed.
+func (s *Server) ExpireCommitConfirmed() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.rollback == nil {
+		return
+	}
+	s.running = s.rollback
+	s.rollback = nil
+}
+
+// Commits returns the number of commits done on the server.
+func (s *Server) Commits() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.commits
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == s.Username && string(pass) == s.Password {
+				return nil, nil
+			}
+
+			return nil, errors.New("permission denied")
+		},
+	}
+	config.AddHostKey(s.hostKey)
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(conn, config)
+		}()
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn, config *ssh.ServerConfig) {
+	defer conn.Close()
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleChannel(channel, requests)
+	}
+}
+
+func (s *Server) handleChannel(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		// payload of subsystem request is a ssh string (uint32 length + name)
+		if req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "netconf" {
+			_ = req.Reply(true, nil)
+			go func() {
+				for req := range requests {
+					_ = req.Reply(false, nil)
+				}
+			}()
+			s.handleNetconf(channel)
+
+			return
+		}
+		_ = req.Reply(false, nil)
+	}
+}
+
+func (s *Server) handleNetconf(rw io.ReadWriter) {
+	s.mutex.Lock()
+	s.nextSessionID++
+	sess := &session{id: s.nextSessionID}
+	s.mutex.Unlock()
+	defer s.endSession(sess)
+
+	hello := fmt.Sprintf("<hello xmlns=\"urn:ietf:params:xml:ns:netconf:base:1.0\"><capabilities>"+
+		"<capability>urn:ietf:params:netconf:base:1.0</capability>"+
+		"<capability>http://xml.juniper.net/netconf/junos/1.0</capability>"+
+		"</capabilities><session-id>%d</session-id></hello>", sess.id)
+	if _, err := io.WriteString(rw, hello+msgSeparator); err != nil {
+		return
+	}
+	reader := bufio.NewReader(rw)
+	// the first message is the hello of the client
+	if _, err := readMessage(reader); err != nil {
+		return
+	}
+	for {
+		msg, err := readMessage(reader)
+		if err != nil {
+			return
+		}
+		var rpc struct {
+			MessageID string `xml:"message-id,attr"`
+			Inner     string `xml:",innerxml"`
+		}
+		if err := xml.Unmarshal(msg, &rpc); err != nil {
+			return
+		}
+		action := Continue
+		if s.RPCHook != nil {
+			action = s.RPCHook(rpcMethod(rpc.Inner))
+		}
+		if action == DropBefore {
+			return
+		}
+		reply, closeSession := s.handleRPC(sess, rpc.Inner)
+		if action == DropAfter {
+			return
+		}
+		if _, err := io.WriteString(rw, fmt.Sprintf(
+			"<rpc-reply xmlns=\"urn:ietf:params:xml:ns:netconf:base:1.0\" message-id=\"%s\">%s</rpc-reply>%s",
+			rpc.MessageID, reply, msgSeparator)); err != nil {
+			return
+		}
+		if closeSession {
+			return
+		}
+	}
+}
+
+// readMessage reads a netconf 1.0 message until the separator.
+func readMessage(reader *bufio.Reader) ([]byte, error) {
+	var msg bytes.Buffer
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		msg.WriteByte(b)
+		if bytes.HasSuffix(msg.Bytes(), []byte(msgSeparator)) {
+			return bytes.TrimSpace(bytes.TrimSuffix(msg.Bytes(), []byte(msgSeparator))), nil
+		}
+	}
+}
+
+// rpcMethod returns the name of the first element of rpc.
+func rpcMethod(rpc string) string {
+	decoder := xml.NewDecoder(strings.NewReader(rpc))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local
+		}
+	}
+}
+
+// endSession releases the lock and discards the uncommitted changes of the session.
+func (s *Server) endSession(sess *session) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.lockSessionID == sess.id {
+		s.lockSessionID = 0
+		s.sharedOps = nil
+	}
+}
+
+// handleRPC returns the content of rpc-reply for the rpc and if the session need to be closed.
+func (s *Server) handleRPC(sess *session, rpc string) (string, bool) {
+	decoder := xml.NewDecoder(strings.NewReader(rpc))
+	var start xml.StartElement
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return rpcError("operation-failed", "syntax error, expecting <rpc> method", 0), false
+		}
+		if t, ok := token.(xml.StartElement); ok {
+			start = t
+
+			break
+		}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch start.Name.Local {
+	case "get-system-information":
+		output, _ := xml.Marshal(struct {
+			XMLName xml.Name `xml:"system-information"`
+			SystemInformation
+		}{SystemInformation: s.SystemInformation})
+
+		return string(output), false
+	case "command":
+		var command struct {
+			Text string `xml:",chardata"`
+		}
+		if err := decoder.DecodeElement(&command, &start); err != nil {
+			return rpcError("operation-failed", err.Error(), 0), false
+		}
+
+		return s.command(strings.TrimSpace(command.Text)), false
+	case "get-interface-information":
+		var getInt struct {
+			Name string `xml:"interface-name"`
+		}
+		if err := decoder.DecodeElement(&getInt, &start); err != nil {
+			return rpcError("operation-failed", err.Error(), 0), false
+		}
+		if !s.interfaceExists(strings.TrimSpace(getInt.Name)) {
+			return "<output>\nerror: device " + getInt.Name + " not found\n</output>", false
+		}
+
+		return "<interface-information><physical-interface><name>" + getInt.Name +
+			"</name></physical-interface></interface-information>", false
+	case "get-configuration":
+		compare := false
+		for _, attr := range start.Attr {
+			if attr.Name.Local == "compare" && attr.Value == "rollback" {
+				compare = true
+			}
+		}
+		ops := s.sharedOps
+		if sess.private {
+			ops = sess.ops
+		}
+		if !compare {
+			var get struct {
+				Filter struct {
+					Inner string `xml:",innerxml"`
+				} `xml:"configuration"`
+			}
+			if err := decoder.DecodeElement(&get, &start); err != nil {
+				return rpcError("operation-failed", err.Error(), 0), false
+			}
+			output, err := configXML(applyOps(s.running, ops), get.Filter.Inner)
+			if err != nil {
+				return rpcError("invalid-value", err.Error(), 0), false
+			}
+
+			return output, false
+		}
+		output := compareConfig(s.running, applyOps(s.running, ops))
+
+		return "<configuration-information><configuration-output>\n" +
+			escapeText(strings.Join(output, "\n")) +
+			"\n</configuration-output></configuration-information>", false
+	case "load-configuration":
+		var load struct {
+			Action string `xml:"action,attr"`
+			Set    string `xml:"configuration-set"`
+		}
+		if err := decoder.DecodeElement(&load, &start); err != nil {
+			return rpcError("operation-failed", err.Error(), 0), false
+		}
+		if load.Action != "set" {
+			return rpcError("operation-not-supported", "only action set is supported", 0), false
+		}
+		if s.lockSessionID != 0 && s.lockSessionID != sess.id && !sess.private {
+			return s.lockDenied(), false
+		}
+		ops, err := parseConfigSet(load.Set)
+		if err != nil {
+			return rpcError("invalid-value", err.Error(), 0), false
+		}
+		if sess.private {
+			sess.ops = append(sess.ops, ops...)
+		} else {
+			s.sharedOps = append(s.sharedOps, ops...)
+		}
+
+		return "\n<load-configuration-results>\n<ok/>\n</load-configuration-results>\n", false
+	case "lock":
+		if s.lockSessionID != 0 && s.lockSessionID != sess.id {
+			return s.lockDenied(), false
+		}
+		if s.lockSessionID == 0 && len(s.sharedOps) > 0 {
+			return rpcError("lock-denied", "configuration database modified", 0), false
+		}
+		s.lockSessionID = sess.id
+
+		return "\n<ok/>\n", false
+	case "unlock":
+		if s.lockSessionID != sess.id {
+			return rpcError("operation-failed", "configuration database not locked", 0), false
+		}
+		s.lockSessionID = 0
+
+		return "\n<ok/>\n", false
+	case "open-configuration":
+		if s.lockSessionID != 0 {
+			return s.lockDenied(), false
+		}
+		if len(s.sharedOps) > 0 {
+			return rpcError("operation-failed", "shared configuration database modified", 0), false
+		}
+		sess.private = true
+		sess.ops = nil
+
+		return "\n<ok/>\n", false
+	case "close-configuration":
+		sess.private = false
+		sess.ops = nil
+
+		return "\n<ok/>\n", false
+	case "delete-config":
+		if s.lockSessionID != 0 && s.lockSessionID != sess.id {
+			return s.lockDenied(), false
+		}
+		s.sharedOps = nil
+
+		return "\n<ok/>\n", false
+	case "commit-configuration":
+		var commit struct {
+			Check     *struct{} `xml:"check"`
+			Confirmed *struct{} `xml:"confirmed"`
+			Log       string    `xml:"log"`
+		}
+		if err := decoder.DecodeElement(&commit, &start); err != nil {
+			return rpcError("operation-failed", err.Error(), 0), false
+		}
+
+		return s.commit(sess, commit.Check != nil, commit.Confirmed != nil, commit.Log), false
+	case "close-session":
+		return "\n<ok/>\n", true
+	default:
+		return rpcError("operation-not-supported", "syntax error, unsupported method "+start.Name.Local, 0), false
+	}
+}
+
+// command answers to the show commands used by the provider.
+func (s *Server) command(command string) string {
+	words := splitWords(command)
+	switch {
+	case len(words) >= 2 && words[0] == "show" && words[1] == "configuration":
+		path := make([]string, 0)
+		pipe := make([]string, 0)
+		for i, w := range words[2:] {
+			if w == "|" {
+				pipe = words[2+i+1:]
+
+				break
+			}
+			path = append(path, w)
+		}
+		if len(pipe) < 2 || pipe[0] != "display" || pipe[1] != "set" {
+			return rpcError("operation-not-supported", "only '| display set [relative]' is supported", 0)
+		}
+		relative := len(pipe) > 2 && pipe[2] == "relative"
+		output, found := displaySet(s.running, path, relative)
+		if !found {
+			return "\n"
+		}
+
+		return "<configuration-information><configuration-output>\n" +
+			escapeText(strings.Join(output, "\n")) +
+			"\n</configuration-output></configuration-information>"
+	case len(words) == 3 && words[0] == "show" && words[1] == "system" && words[2] == "commit":
+		output := make([]string, 0)
+		for i, entry := range s.commitHistory {
+			output = append(output, fmt.Sprintf("%-3d %s by %s via netconf",
+				i, entry.time.UTC().Format("2006-01-02 15:04:05 MST"), s.Username))
+			if entry.log != "" {
+				output = append(output, "    "+entry.log)
+			}
+		}
+
+		return "<output>\n" + escapeText(strings.Join(output, "\n")) + "\n</output>"
+	case len(words) >= 3 && words[0] == "show" && words[1] == "interfaces" && words[len(words)-1] == "terse":
+		output := []string{"Interface               Admin Link Proto    Local                 Remote"}
+		for _, name := range s.interfaces() {
+			if len(words) == 4 && !strings.HasPrefix(name, words[2]) {
+				continue
+			}
+			output = append(output, name+"                up    up")
+		}
+
+		return "<output>\n" + escapeText(strings.Join(output, "\n")) + "\n</output>"
+	default:
+		return rpcError("operation-not-supported", "syntax error, unsupported command: "+command, 0)
+	}
+}
+
+// commit commits the candidate configuration (shared or private) or only checks it,
+// a commit without confirmed confirms a pending commit confirmed.
+func (s *Server) commit(sess *session, check, confirmed bool, log string) string {
+	ops := s.sharedOps
+	if sess.private {
+		ops = sess.ops
+	} else if s.lockSessionID != 0 && s.lockSessionID != sess.id {
+		return s.lockDenied()
+	}
+	config := applyOps(s.running, ops)
+	reply := "\n<ok/>\n"
+	if s.CommitHook != nil {
+		lines := make([]string, 0, len(config))
+		for _, words := range config {
+			lines = append(lines, "set "+strings.Join(words, " "))
+		}
+		if err := s.CommitHook(lines); err != nil {
+			var commitErrs CommitErrors
+			if !errors.As(err, &commitErrs) {
+				var commitErr *CommitError
+				if !errors.As(err, &commitErr) {
+					return "<commit-results>" + rpcError("operation-failed", err.Error(), 0) + "</commit-results>"
+				}
+				commitErrs = CommitErrors{commitErr}
+			}
+			results := ""
+			failed := false
+			for _, commitErr := range commitErrs {
+				results += commitError(commitErr)
+				if !commitErr.Warning {
+					failed = true
+				}
+			}
+			if failed {
+				return "<commit-results>" + results + "</commit-results>"
+			}
+			reply = "<commit-results>" + results + "\n<commit-check-success/>\n</commit-results>"
+		}
+	}
+	if check {
+		return reply
+	}
+	switch {
+	case confirmed && s.rollback == nil:
+		s.rollback = s.running
+	case !confirmed:
+		s.rollback = nil
+	}
+	s.running = config
+	s.commits++
+	s.commitHistory = append([]commitEntry{{time: time.Now(), log: log}}, s.commitHistory...)
+	if sess.private {
+		sess.ops = nil
+	} else {
+		s.sharedOps = nil
+	}
+
+	return reply
+}
+
+// interfaces returns the physical interfaces and the logical interfaces in the configuration.
+func (s *Server) interfaces() []string {
+	names := append(make([]string, 0), s.Interfaces...)
+	for _, words := range s.running {
+		if len(words) < 2 || words[0] != "interfaces" {
+			continue
+		}
+		if !stringInSlice(words[1], names) {
+			names = append(names, words[1])
+		}
+		if len(words) >= 4 && words[2] == "unit" && !stringInSlice(words[1]+"."+words[3], names) {
+			names = append(names, words[1]+"."+words[3])
+		}
+	}
+
+	return names
+}
+
+func (s *Server) interfaceExists(name string) bool {
+	if stringInSlice(name, s.Interfaces) {
+		return true
+	}
+	for _, words := range s.running {
+		if len(words) >= 2 && words[0] == "interfaces" && words[1] == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) lockDenied() string {
+	return rpcError("lock-denied", fmt.Sprintf("configuration database locked by:\n"+
+		"  netconf terminal ? (pid %d) on since 2021-01-01 00:00:00 UTC\n"+
+		"      exclusive [edit]", s.lockSessionID), s.lockSessionID)
+}
+
+func rpcError(tag, message string, sessionID int) string {
+	info := ""
+	if sessionID != 0 {
+		info = fmt.Sprintf("\n<error-info>\n<session-id>%d</session-id>\n</error-info>", sessionID)
+	}
+
+	return "\n<rpc-error>\n<error-type>protocol</error-type>\n<error-tag>" + tag + "</error-tag>\n" +
+		"<error-severity>error</error-severity>\n<error-message>\n" + escapeText(message) +
+		"\n</error-message>" + info + "\n</rpc-error>\n"
+}
+
+// commitError returns the rpc-error of a commit error with its path and element.
+func commitError(err *CommitError) string {
+	severity := "error"
+	if err.Warning {
+		severity = "warning"
+	}
+
+	return "\n<rpc-error>\n<error-type>protocol</error-type>\n<error-tag>operation-failed</error-tag>\n" +
+		"<error-severity>" + severity + "</error-severity>\n<error-path>\n" + escapeText(err.Path) +
+		"\n</error-path>\n<error-info>\n<bad-element>" + escapeText(err.Element) + "</bad-element>\n" +
+		"</error-info>\n<error-message>\n" + escapeText(err.Message) + "\n</error-message>\n</rpc-error>\n"
+}
+
+// escapeText escapes text like Junos (quotes and newlines are not escaped).
+func escapeText(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+func stringInSlice(str string, list []string) bool {
+	for _, v := range list {
+		if v == str {
+			return true
+		}
+	}
+
+	return false
+}
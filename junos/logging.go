@@ -0,0 +1,202 @@
+package junos
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// redactedWord : replacement of secrets in logs.
+const redactedWord = "<redacted>"
+
+var (
+	// secretWords : Junos configuration keywords followed by a secret.
+	secretWords = []string{
+		"authentication-key",
+		"authentication-password",
+		"encrypted-password",
+		"simple-password",
+		"privacy-password",
+		"shared-secret",
+		"ascii-text",
+		"hexadecimal",
+		"secret",
+		"password",
+	}
+	// secretSetRegexp : secret in a set line ('... secret "$9$..."').
+	secretSetRegexp = regexp.MustCompile(`\b(` + strings.Join(secretWords, "|") + `)( +)("(?:[^"\\]|\\.)*"|[^ \n"<]+)`)
+	// secretXMLRegexp : secret in a XML element ('<secret>$9$...</secret>').
+	secretXMLRegexp = regexp.MustCompile(`<(` + strings.Join(secretWords, "|") + `)>[^<]*</`)
+)
+
+// newLogger returns the logger of provider.
+// Entries are written in JSON on stderr, read by Terraform and displayed with TF_LOG_PROVIDER or TF_LOG,
+// and, if logPath is set, in text to this file with all levels.
+// Secrets in entries are redacted.
+func newLogger(logPath string) (hclog.Logger, error) {
+	level := hclog.LevelFromString(os.Getenv("TF_LOG_PROVIDER"))
+	if level == hclog.NoLevel {
+		level = hclog.LevelFromString(os.Getenv("TF_LOG"))
+	}
+	if level == hclog.NoLevel {
+		level = hclog.Off
+		if os.Getenv("TF_LOG_PROVIDER") != "" || os.Getenv("TF_LOG") != "" {
+			level = hclog.Trace
+		}
+	}
+	logger := hclog.NewInterceptLogger(&hclog.LoggerOptions{
+		Name:       "junos",
+		Level:      level,
+		Output:     os.Stderr,
+		JSONFormat: true,
+	})
+	if logPath != "" {
+		f, err := os.OpenFile(logPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open debug_netconf_log_path : %w", err)
+		}
+		logger.RegisterSink(hclog.NewSinkAdapter(&hclog.LoggerOptions{
+			Level:  hclog.Trace,
+			Output: f,
+		}))
+	}
+
+	return redactLogger{logger}, nil
+}
+
+// log returns the logger of session (a logger without output if not set).
+func (sess *Session) log() hclog.Logger {
+	if sess.logger == nil {
+		return hclog.NewNullLogger()
+	}
+
+	return sess.logger
+}
+
+// log returns the logger of netconf session (a logger without output if not set).
+func (j *NetconfObject) log() hclog.Logger {
+	if j.logger == nil {
+		return hclog.NewNullLogger()
+	}
+
+	return j.logger
+}
+
+// redactLogger : logger which redacts secrets in message and values of entries.
+type redactLogger struct {
+	hclog.Logger
+}
+
+func (l redactLogger) Log(level hclog.Level, msg string, args ...interface{}) {
+	l.Logger.Log(level, redactSecrets(msg), redactArgs(args)...)
+}
+
+func (l redactLogger) Trace(msg string, args ...interface{}) {
+	l.Logger.Trace(redactSecrets(msg), redactArgs(args)...)
+}
+
+func (l redactLogger) Debug(msg string, args ...interface{}) {
+	l.Logger.Debug(redactSecrets(msg), redactArgs(args)...)
+}
+
+func (l redactLogger) Info(msg string, args ...interface{}) {
+	l.Logger.Info(redactSecrets(msg), redactArgs(args)...)
+}
+
+func (l redactLogger) Warn(msg string, args ...interface{}) {
+	l.Logger.Warn(redactSecrets(msg), redactArgs(args)...)
+}
+
+func (l redactLogger) Error(msg string, args ...interface{}) {
+	l.Logger.Error(redactSecrets(msg), redactArgs(args)...)
+}
+
+func (l redactLogger) With(args ...interface{}) hclog.Logger {
+	return redactLogger{l.Logger.With(redactArgs(args)...)}
+}
+
+func (l redactLogger) ImpliedArgs() []interface{} {
+	return redactArgs(l.Logger.ImpliedArgs())
+}
+
+func (l redactLogger) Named(name string) hclog.Logger {
+	return redactLogger{l.Logger.Named(name)}
+}
+
+func (l redactLogger) ResetNamed(name string) hclog.Logger {
+	return redactLogger{l.Logger.ResetNamed(name)}
+}
+
+func (l redactLogger) StandardLogger(opts *hclog.StandardLoggerOptions) *log.Logger {
+	return log.New(l.StandardWriter(opts), "", 0)
+}
+
+func (l redactLogger) StandardWriter(opts *hclog.StandardLoggerOptions) io.Writer {
+	return redactWriter{l.Logger.StandardWriter(opts)}
+}
+
+// redactWriter : writer of standard logger which redacts secrets before write.
+type redactWriter struct {
+	io.Writer
+}
+
+func (w redactWriter) Write(data []byte) (int, error) {
+	if _, err := w.Writer.Write([]byte(redactSecrets(string(data)))); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// redactArgs returns args with secrets redacted in values (string, list of strings and error).
+func redactArgs(args []interface{}) []interface{} {
+	result := make([]interface{}, len(args))
+	for i, arg := range args {
+		if i%2 == 0 {
+			result[i] = arg
+
+			continue
+		}
+		switch v := arg.(type) {
+		case string:
+			result[i] = redactSecrets(v)
+		case []string:
+			values := make([]string, len(v))
+			for j, s := range v {
+				values[j] = redactSecrets(s)
+			}
+			result[i] = values
+		case error:
+			result[i] = redactSecrets(v.Error())
+		default:
+			result[i] = arg
+		}
+	}
+
+	return result
+}
+
+// redactSecrets replaces the secrets in configuration lines or XML elements.
+func redactSecrets(s string) string {
+	s = secretSetRegexp.ReplaceAllString(s, `${1}${2}`+redactedWord)
+
+	return secretXMLRegexp.ReplaceAllString(s, `<${1}>`+redactedWord+`</`)
+}
+
+// rpcMethod returns the name of the first element of rpc.
+func rpcMethod(rpc string) string {
+	rpc = strings.TrimSpace(rpc)
+	if !strings.HasPrefix(rpc, "<") {
+		return ""
+	}
+	if end := strings.IndexAny(rpc, " />"); end > 0 {
+		return rpc[1:end]
+	}
+
+	return strings.TrimPrefix(rpc, "<")
+}
@@ -0,0 +1,119 @@
+package junos
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"set_line": {
+			input: `set protocols bgp group G1 authentication-key "$9$abc def"`,
+			want:  `set protocols bgp group G1 authentication-key <redacted>`,
+		},
+		"set_line_unquoted": {
+			input: "set system radius-server 192.0.2.1 secret $9$abc\nset system radius-server 192.0.2.1 port 1812",
+			want:  "set system radius-server 192.0.2.1 secret <redacted>\nset system radius-server 192.0.2.1 port 1812",
+		},
+		"pre_shared_key": {
+			input: `set security ike policy P1 pre-shared-key ascii-text "$9$xyz"`,
+			want:  `set security ike policy P1 pre-shared-key ascii-text <redacted>`,
+		},
+		"encrypted_password": {
+			input: `set system login user admin authentication encrypted-password "$6$salt$hash"`,
+			want:  `set system login user admin authentication encrypted-password <redacted>`,
+		},
+		"xml": {
+			input: "<user><name>admin</name><encrypted-password>$6$salt$hash</encrypted-password></user>",
+			want:  "<user><name>admin</name><encrypted-password><redacted></encrypted-password></user>",
+		},
+		"without_secret": {
+			input: "set system login retry-options tries-before-disconnect 3",
+			want:  "set system login retry-options tries-before-disconnect 3",
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			if got := redactSecrets(tt.input); got != tt.want {
+				t.Errorf("redactSecrets(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggerFile(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "netconf.log")
+	logger, err := newLogger(logPath)
+	if err != nil {
+		t.Fatalf("newLogger: %s", err)
+	}
+	logger.With("ip", "192.0.2.1").Debug("load configuration lines",
+		"lines", []string{`set system radius-server 192.0.2.10 secret "$9$abc"`},
+		"error", errors.New(`error in secret "$9$abc"`))
+	content, err := ioutil.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read log file: %s", err)
+	}
+	if strings.Contains(string(content), "$9$abc") {
+		t.Errorf("secret not redacted in log file: %q", content)
+	}
+	for _, want := range []string{"load configuration lines", "ip=192.0.2.1", "secret <redacted>"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("log file %q doesn't contain %q", content, want)
+		}
+	}
+}
+
+func TestLoggerFileNamed(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "netconf.log")
+	logger, err := newLogger(logPath)
+	if err != nil {
+		t.Fatalf("newLogger: %s", err)
+	}
+	named := logger.Named("batch")
+	named.Info("commit lines", "lines", []string{`set snmp community public authentication-key "$9$named"`})
+	named.With("rpc", `<secret>$9$with</secret>`).Warn("rpc")
+	logger.ResetNamed("other").Error(`failed on secret "$9$reset"`)
+	named.StandardLogger(&hclog.StandardLoggerOptions{}).Printf(`[INFO] shared-secret "$9$standard"`)
+	for _, arg := range named.With("line", `secret "$9$implied"`).ImpliedArgs() {
+		if s, ok := arg.(string); ok && strings.Contains(s, "$9$implied") {
+			t.Errorf("secret not redacted in implied args: %q", s)
+		}
+	}
+	content, err := ioutil.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read log file: %s", err)
+	}
+	if strings.Contains(string(content), "$9$") {
+		t.Errorf("secret not redacted in log file: %q", content)
+	}
+	for _, want := range []string{
+		"junos.batch: commit lines", "other: failed on secret <redacted>", "shared-secret <redacted>",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("log file %q doesn't contain %q", content, want)
+		}
+	}
+}
+
+func TestRPCMethod(t *testing.T) {
+	for rpc, want := range map[string]string{
+		"<get-system-information/>":                       "get-system-information",
+		"<command format=\"text\">show version</command>": "command",
+		" <lock><target><candidate/></target></lock>":     "lock",
+		"show version": "",
+	} {
+		if got := rpcMethod(rpc); got != want {
+			t.Errorf("rpcMethod(%q) = %q, want %q", rpc, got, want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// logicalSystemSeparator : separator between the logical system (or tenant) and the id of resource.
+const logicalSystemSeparator = "_#_"
+
+// logicalSystemIDPrefix returns the prefix of id for the resources configured
+// under the logical system or tenant of sess (logical-systems:<name>_#_ or tenants:<name>_#_),
+// empty if not set.
+func (sess *Session) logicalSystemIDPrefix() string {
+	if sess.junosLogicalSystem != "" {
+		return "logical-systems:" + sess.junosLogicalSystem + logicalSystemSeparator
+	}
+	if sess.junosTenant != "" {
+		return "tenants:" + sess.junosTenant + logicalSystemSeparator
+	}
+
+	return ""
+}
+
+// splitLogicalSystemID returns the logical system, the tenant and the id of resource without prefix
+// from an id prefixed by logical-systems:<name> or tenants:<name> and logicalSystemSeparator.
+func splitLogicalSystemID(id string) (logicalSystem, tenant, resourceID string, err error) {
+	if !strings.Contains(id, logicalSystemSeparator) {
+		return "", "", id, nil
+	}
+	idSplit := strings.SplitN(id, logicalSystemSeparator, 2)
+	system := strings.SplitN(idSplit[0], ":", 2)
+	if len(system) != 2 || system[1] == "" {
+		return "", "", "", fmt.Errorf("can't find logical system or tenant in id %q "+
+			"(id must be logical-systems:<name>%s<id> or tenants:<name>%s<id>)",
+			id, logicalSystemSeparator, logicalSystemSeparator)
+	}
+	switch system[0] {
+	case "logical-systems":
+		return system[1], "", idSplit[1], nil
+	case "tenants":
+		return "", system[1], idSplit[1], nil
+	default:
+		return "", "", "", fmt.Errorf("unknown %q in id %q, need to be logical-systems or tenants",
+			system[0], id)
+	}
+}
+
+// withIDLogicalSystem returns the id without the prefix of logical system or tenant and
+// a copy of sess to configure the resource under the logical system or tenant of this prefix.
+// Without prefix, sess is returned (resources are configured under the logical system or tenant of sess).
+func (sess *Session) withIDLogicalSystem(id string) (*Session, string, error) {
+	logicalSystem, tenant, resourceID, err := splitLogicalSystemID(id)
+	if err != nil {
+		return nil, "", err
+	}
+	if logicalSystem == "" && tenant == "" {
+		return sess, resourceID, nil
+	}
+	if logicalSystem == sess.junosLogicalSystem && tenant == sess.junosTenant {
+		return sess, resourceID, nil
+	}
+	sessCopy := *sess
+	sessCopy.junosLogicalSystem = logicalSystem
+	sessCopy.junosTenant = tenant
+	if logicalSystem != "" {
+		sessCopy.logger = sess.log().With("logical_system", logicalSystem)
+	} else {
+		sessCopy.logger = sess.log().With("tenant", tenant)
+	}
+
+	return &sessCopy, resourceID, nil
+}
+
+// resourcesWithLogicalSystem adds the logical system or tenant in the id of resources.
+// The id in state is prefixed with the logical system (or tenant) of provider (or device) configuration
+// and logicalSystemSeparator, actions on a resource with this prefix (like import) are done under
+// the logical system or tenant of the prefix.
+func resourcesWithLogicalSystem(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, res := range resources {
+		res.CreateContext = logicalSystemResourceAction(res.CreateContext)
+		res.ReadContext = logicalSystemResourceAction(res.ReadContext)
+		res.UpdateContext = logicalSystemResourceAction(res.UpdateContext)
+		res.DeleteContext = logicalSystemResourceAction(res.DeleteContext)
+		if res.CustomizeDiff != nil {
+			res.CustomizeDiff = logicalSystemCustomizeDiff(res.CustomizeDiff)
+		}
+		if res.Importer != nil && res.Importer.StateContext != nil {
+			res.Importer.StateContext = logicalSystemImport(res.Importer.StateContext)
+		}
+	}
+
+	return resources
+}
+
+func logicalSystemResourceAction(
+	action func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if action == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		sess, id, err := m.(*Session).withIDLogicalSystem(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if d.Id() != "" {
+			d.SetId(id)
+		}
+		diags := action(ctx, d, sess)
+		if d.Id() != "" {
+			d.SetId(sess.logicalSystemIDPrefix() + d.Id())
+		}
+
+		return diags
+	}
+}
+
+func logicalSystemCustomizeDiff(customizeDiff schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		sess, _, err := m.(*Session).withIDLogicalSystem(diff.Id())
+		if err != nil {
+			return err
+		}
+
+		return customizeDiff(ctx, diff, sess)
+	}
+}
+
+// logicalSystemImport imports resource with an id prefixed by a logical system (or tenant)
+// and logicalSystemSeparator, without prefix the resource is imported
+// from the logical system or tenant of provider (or device) configuration.
+func logicalSystemImport(importState schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		sess, id, err := m.(*Session).withIDLogicalSystem(d.Id())
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+		result, err := importState(ctx, d, sess)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range result {
+			r.SetId(sess.logicalSystemIDPrefix() + r.Id())
+		}
+
+		return result, nil
+	}
+}
@@ -1,16 +1,29 @@
 package junos
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/jeremmfr/go-netconf/netconf"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
-const warningSeverity string = "warning"
+const (
+	warningSeverity string = "warning"
+	// netconfCleanupTimeout : timeout of RPCs to clean up a session (clear, unlock, close)
+	// not interrupted by the end of the context of session.
+	netconfCleanupTimeout = 2 * time.Minute
+)
 
 var (
 	rpcCommand         = "<command format=\"text\">%s</command>"
@@ -18,16 +31,46 @@ var (
 		"<configuration-set>%s</configuration-set></load-configuration>"
 	rpcSystemInfo      = "<get-system-information/>"
 	rpcCommit          = "<commit-configuration><log>%s</log></commit-configuration>"
+	rpcCommitConfirmed = "<commit-configuration><confirmed/><confirm-timeout>%d</confirm-timeout>" +
+		"<log>%s</log></commit-configuration>"
+	rpcCommitCheck     = "<commit-configuration><check/></commit-configuration>"
 	rpcCandidateLock   = "<lock><target><candidate/></target></lock>"
 	rpcCandidateUnlock = "<unlock><target><candidate/></target></unlock>"
 	rpcClearCandidate  = "<delete-config><target><candidate/></target></delete-config>"
 	rpcClose           = "<close-session/>"
+	rpcOpenPrivate     = "<open-configuration><private/></open-configuration>"
+	rpcCloseConfig     = "<close-configuration/>"
+	rpcCompareConfig   = "<get-configuration compare=\"rollback\" rollback=\"0\" format=\"text\"/>"
+	rpcGetConfig       = "<get-configuration><configuration>%s</configuration></get-configuration>"
 )
 
 // NetconfObject : store Junos device info and session.
 type NetconfObject struct {
+	// locked : candidate configuration locked by this session.
+	locked bool
+	// broken : transport failed or closed, session can't be reused.
+	broken bool
+	// closed : session already closed.
+	closed bool
+	// private : private candidate configuration opened by this session.
+	private bool
+	// interrupted : transport closed at the end of context during a rpc.
+	interrupted bool
+	// batching : changes collected for a batch commit instead of loaded on the device.
+	batching bool
+	// batchAdded : changes collected added to a batch (waiting for the commit of the batch).
+	batchAdded bool
+	batchLines []string
+	// loadedLines : configuration lines loaded in the candidate configuration since the lock,
+	// loaded again after a reconnect.
+	loadedLines       []string
+	batchDeadline     time.Time
+	logger            hclog.Logger
+	ctx               context.Context
 	Session           *netconf.Session
 	SystemInformation sysInfo `xml:"system-information"`
+	// commitSlot : commit slot of the device kept by this session (from the lock to the unlock).
+	commitSlot chan struct{}
 }
 
 type sysInfo struct {
@@ -48,11 +91,20 @@ type commandXMLConfig struct {
 	Config string `xml:",innerxml"`
 }
 type netconfAuthMethod struct {
-	Password       string
-	Username       string
-	PrivateKeyPEM  string
-	PrivateKeyFile string
-	Passphrase     string
+	InsecureIgnoreHostKey bool
+	Password              string
+	Username              string
+	PrivateKeyPEM         string
+	PrivateKeyFile        string
+	Passphrase            string
+	KnownHostsFile        string
+	HostKeyFingerprints   []string
+	methods               []string
+	agentConn             net.Conn
+}
+type netconfBastion struct {
+	Host string
+	Auth netconfAuthMethod
 }
 type commitError struct {
 	Path     string `xml:"error-path"`
@@ -60,6 +112,28 @@ type commitError struct {
 	Message  string `xml:"error-message"`
 	Severity string `xml:"error-severity"`
 }
+
+// commitRPCError : error of a commit with the configuration path and the element in error.
+type commitRPCError struct {
+	path    string
+	element string
+	message string
+}
+
+func (e *commitRPCError) Error() string {
+	return fmt.Sprintf("[%s]\n    %s\nError: %s", e.path, e.element, e.message)
+}
+
+// lockDeniedError : rpc-error when the candidate configuration can't be locked (or opened)
+// with the session and the user holding the lock if found.
+type lockDeniedError struct {
+	sessionID string
+	user      string
+	message   string
+}
+type lockDeniedInfo struct {
+	SessionID string `xml:"error-info>session-id"`
+}
 type commitResults struct {
 	XMLName xml.Name      `xml:"commit-results"`
 	Errors  []commitError `xml:"rpc-error"`
@@ -68,14 +142,59 @@ type commitResults struct {
 // netconfNewSession establishes a new connection to a NetconfObject device that we will use
 // to run our commands against.
 // Authentication methods are defined using the netconfAuthMethod struct, and are as follows:
-// username and password, SSH private key (with or without passphrase).
-func netconfNewSession(host string, auth *netconfAuthMethod) (*NetconfObject, error) {
+// ssh-agent, SSH private key (with or without passphrase), keyboard-interactive and password.
+// If bastion is not nil, the connection to the device is tunnelled through the bastion host.
+func netconfNewSession(host string, auth *netconfAuthMethod, bastion *netconfBastion) (*NetconfObject, error) {
 	clientConfig, err := genSSHClientConfig(auth)
 	if err != nil {
 		return nil, err
 	}
+	defer auth.closeAgent()
+	var jnpr *NetconfObject
+	if bastion != nil {
+		jnpr, err = netconfNewSessionWithBastion(host, clientConfig, bastion)
+	} else {
+		jnpr, err = netconfNewSessionWithConfig(host, clientConfig)
+	}
+	if err != nil && strings.Contains(err.Error(), "unable to authenticate") {
+		return jnpr, fmt.Errorf("%w (authentication methods tried : %s)", err, strings.Join(auth.methods, ", "))
+	}
 
-	return netconfNewSessionWithConfig(host, clientConfig)
+	return jnpr, err
+}
+
+// netconfNewSessionContext establishes a new connection like netconfNewSession
+// but stops waiting at the end of ctx (the connection is closed when it's finally established).
+func netconfNewSessionContext(ctx context.Context, host string, auth *netconfAuthMethod,
+	bastion *netconfBastion) (*NetconfObject, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("error connecting to %s - %w", host, err)
+	}
+	type dialResult struct {
+		jnpr *NetconfObject
+		err  error
+	}
+	done := make(chan dialResult, 1)
+	go func() {
+		jnpr, err := netconfNewSession(host, auth, bastion)
+		done <- dialResult{jnpr: jnpr, err: err}
+	}()
+	select {
+	case result := <-done:
+		if result.jnpr != nil {
+			result.jnpr.ctx = ctx
+		}
+
+		return result.jnpr, result.err
+	case <-ctx.Done():
+		go func() {
+			if result := <-done; result.jnpr != nil {
+				_ = result.jnpr.Session.Transport.Close()
+			}
+		}()
+
+		return nil, fmt.Errorf("error connecting to %s - %w", host, ctx.Err())
+	}
 }
 
 // netconfNewSessionWithConfig establishes a new connection to a NetconfObject device that we will use
@@ -89,6 +208,102 @@ func netconfNewSessionWithConfig(host string, clientConfig *ssh.ClientConfig) (*
 	return newSessionFromNetconf(s)
 }
 
+// netconfNewSessionWithBastion establishes a new connection to a NetconfObject device
+// with the netconf subsystem tunnelled through a ssh connection to a bastion host.
+func netconfNewSessionWithBastion(host string, clientConfig *ssh.ClientConfig,
+	bastion *netconfBastion) (*NetconfObject, error) {
+	bastionConfig, err := genSSHClientConfig(&bastion.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("bastion %s : %w", bastion.Host, err)
+	}
+	defer bastion.Auth.closeAgent()
+	bastionClient, err := ssh.Dial("tcp", bastion.Host, bastionConfig)
+	if err != nil {
+		if strings.Contains(err.Error(), "unable to authenticate") {
+			return nil, fmt.Errorf("error connecting to bastion %s - %w (authentication methods tried : %s)",
+				bastion.Host, err, strings.Join(bastion.Auth.methods, ", "))
+		}
+
+		return nil, fmt.Errorf("error connecting to bastion %s - %w", bastion.Host, err)
+	}
+	conn, err := bastionClient.Dial("tcp", host)
+	if err != nil {
+		bastionClient.Close()
+
+		return nil, fmt.Errorf("error connecting to %s through bastion %s - %w", host, bastion.Host, err)
+	}
+	t, err := newTransportSSHBastion(conn, host, clientConfig, bastionClient)
+	if err != nil {
+		bastionClient.Close()
+
+		return nil, fmt.Errorf("error connecting to %s through bastion %s - %w", host, bastion.Host, err)
+	}
+
+	return newSessionFromNetconf(netconf.NewSession(t))
+}
+
+// transportSSHBastion : netconf transport on a ssh connection tunnelled through a bastion host.
+// netconf.TransportJunos is only used for the netconf framing on ssh session pipes.
+type transportSSHBastion struct {
+	netconf.TransportJunos
+	sshClient  *ssh.Client
+	sshSession *ssh.Session
+	bastion    *ssh.Client
+}
+
+// newTransportSSHBastion opens the ssh connection to the device on conn and requests the netconf subsystem.
+// host is used to verify the host key of the device (conn doesn't have the real remote address).
+func newTransportSSHBastion(conn net.Conn, host string, config *ssh.ClientConfig,
+	bastion *ssh.Client) (*transportSSHBastion, error) {
+	c, chans, reqs, err := ssh.NewClientConn(conn, host, config)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+	t := &transportSSHBastion{
+		sshClient: ssh.NewClient(c, chans, reqs),
+		bastion:   bastion,
+	}
+	t.sshSession, err = t.sshClient.NewSession()
+	if err != nil {
+		t.sshClient.Close()
+
+		return nil, err
+	}
+	writer, err := t.sshSession.StdinPipe()
+	if err != nil {
+		t.sshClient.Close()
+
+		return nil, err
+	}
+	reader, err := t.sshSession.StdoutPipe()
+	if err != nil {
+		t.sshClient.Close()
+
+		return nil, err
+	}
+	t.ReadWriteCloser = netconf.NewReadWriteCloser(reader, writer)
+	if err := t.sshSession.RequestSubsystem("netconf"); err != nil {
+		t.sshClient.Close()
+
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Close closes the ssh session and the ssh connections to the device and to the bastion.
+func (t *transportSSHBastion) Close() error {
+	t.sshSession.Close()
+	err := t.sshClient.Close()
+	if errBastion := t.bastion.Close(); err == nil {
+		err = errBastion
+	}
+
+	return err
+}
+
 // newSessionFromNetconf uses an existing netconf.Session to run our commands against.
 func newSessionFromNetconf(s *netconf.Session) (*NetconfObject, error) {
 	n := &NetconfObject{
@@ -98,50 +313,317 @@ func newSessionFromNetconf(s *netconf.Session) (*NetconfObject, error) {
 	return n, n.gatherFacts()
 }
 
-// genSSHClientConfig is a wrapper function based around the auth method defined
-// (user/password or private key) which returns the SSH client configuration used to
-// connect.
+// genSSHClientConfig is a wrapper function based around the auth methods defined
+// which returns the SSH client configuration used to connect.
+// Authentication methods are tried in this order :
+// ssh-agent (with SSH_AUTH_SOCK), private key (PEM or file),
+// keyboard-interactive answered with the password and password.
 func genSSHClientConfig(auth *netconfAuthMethod) (*ssh.ClientConfig, error) {
-	var config *ssh.ClientConfig
-
+	config := &ssh.ClientConfig{
+		User: auth.Username,
+	}
+	config.Ciphers = append(config.Ciphers,
+		"aes128-gcm@openssh.com", "chacha20-poly1305@openssh.com",
+		"aes128-ctr", "aes192-ctr", "aes256-ctr",
+		"aes128-cbc")
+	auth.methods = make([]string, 0)
+	signers := make([]ssh.Signer, 0)
+	var agentClient agent.Agent
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		agentConn, err := net.Dial("unix", socket)
+		if err == nil {
+			auth.agentConn = agentConn
+			agentClient = agent.NewClient(agentConn)
+			auth.methods = append(auth.methods, "publickey (ssh-agent)")
+		}
+	}
 	if len(auth.PrivateKeyPEM) > 0 {
-		config, err := netconf.SSHConfigPubKeyPem(auth.Username, []byte(auth.PrivateKeyPEM), auth.Passphrase)
+		signer, err := sshParsePrivateKey([]byte(auth.PrivateKeyPEM), auth.Passphrase)
 		if err != nil {
+			auth.closeAgent()
+
 			return config, fmt.Errorf("failed to create new SSHConfig with PEM private key : %w", err)
 		}
-		config.Ciphers = append(config.Ciphers,
-			"aes128-gcm@openssh.com", "chacha20-poly1305@openssh.com",
-			"aes128-ctr", "aes192-ctr", "aes256-ctr",
-			"aes128-cbc")
-		config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+		signers = append(signers, signer)
+		auth.methods = append(auth.methods, "publickey (PEM private key)")
+	} else if len(auth.PrivateKeyFile) > 0 {
+		key, err := ioutil.ReadFile(auth.PrivateKeyFile)
+		if err != nil {
+			auth.closeAgent()
 
-		return config, nil
-	}
-	if len(auth.PrivateKeyFile) > 0 {
-		config, err := netconf.SSHConfigPubKeyFile(auth.Username, auth.PrivateKeyFile, auth.Passphrase)
+			return config, fmt.Errorf("failed to create new SSHConfig with file private key : %w", err)
+		}
+		signer, err := sshParsePrivateKey(key, auth.Passphrase)
 		if err != nil {
+			auth.closeAgent()
+
 			return config, fmt.Errorf("failed to create new SSHConfig with file private key : %w", err)
 		}
-		config.Ciphers = append(config.Ciphers,
-			"aes128-gcm@openssh.com", "chacha20-poly1305@openssh.com",
-			"aes128-ctr", "aes192-ctr", "aes256-ctr",
-			"aes128-cbc")
-		config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+		signers = append(signers, signer)
+		auth.methods = append(auth.methods, "publickey (file private key)")
+	}
+	// publickey method is tried only once by the ssh client so agent and private key signers are merged
+	if agentClient != nil || len(signers) > 0 {
+		config.Auth = append(config.Auth, ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+			if agentClient == nil {
+				return signers, nil
+			}
+			agentSigners, err := agentClient.Signers()
+			if err != nil {
+				return signers, nil
+			}
 
-		return config, nil
+			return append(agentSigners, signers...), nil
+		}))
 	}
 	if len(auth.Password) > 0 {
-		config = netconf.SSHConfigPassword(auth.Username, auth.Password)
-		config.Ciphers = append(config.Ciphers,
-			"aes128-gcm@openssh.com", "chacha20-poly1305@openssh.com",
-			"aes128-ctr", "aes192-ctr", "aes256-ctr",
-			"aes128-cbc")
+		config.Auth = append(config.Auth,
+			ssh.KeyboardInteractive(func(user, instruction string, questions []string, echos []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range questions {
+					answers[i] = auth.Password
+				}
+
+				return answers, nil
+			}),
+			ssh.Password(auth.Password),
+		)
+		auth.methods = append(auth.methods, "keyboard-interactive", "password")
+	}
+	if len(config.Auth) == 0 {
+		return config, errors.New("no credentials/keys available")
+	}
+	if err := auth.setHostKeyCheck(config); err != nil {
+		auth.closeAgent()
+
+		return config, err
+	}
+
+	return config, nil
+}
+
+// sshParsePrivateKey returns a signer from a private key in PEM format, encrypted with passphrase or not.
+func sshParsePrivateKey(key []byte, passphrase string) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		var passErr *ssh.PassphraseMissingError
+		if errors.As(err, &passErr) {
+			return ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+		}
+
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+// closeAgent closes the connection to ssh-agent opened by genSSHClientConfig.
+func (auth *netconfAuthMethod) closeAgent() {
+	if auth.agentConn != nil {
+		auth.agentConn.Close()
+		auth.agentConn = nil
+	}
+}
+
+// setHostKeyCheck sets the callback to verify the host key of the device in the SSH client configuration
+// with the known_hosts file and/or the pinned fingerprints,
+// ignore the host key only if it's explicitly asked.
+func (auth *netconfAuthMethod) setHostKeyCheck(config *ssh.ClientConfig) error {
+	if auth.InsecureIgnoreHostKey {
 		config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
 
-		return config, nil
+		return nil
+	}
+	if auth.KnownHostsFile == "" && len(auth.HostKeyFingerprints) == 0 {
+		return errors.New("no method to verify the ssh host key of device, " +
+			"set a known_hosts file, host key fingerprints or explicitly ignore the host key")
+	}
+	var knownHostsCallback ssh.HostKeyCallback
+	if auth.KnownHostsFile != "" {
+		var err error
+		knownHostsCallback, err = knownhosts.New(auth.KnownHostsFile)
+		if err != nil {
+			return fmt.Errorf("failed to read known_hosts file %s : %w", auth.KnownHostsFile, err)
+		}
+	}
+	config.HostKeyCallback = func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		for _, v := range auth.HostKeyFingerprints {
+			if hostKeyFingerprintMatch(v, key) {
+				return nil
+			}
+		}
+		if knownHostsCallback == nil {
+			return fmt.Errorf("ssh host key of %s with fingerprint %s doesn't match any pinned fingerprints",
+				hostname, ssh.FingerprintSHA256(key))
+		}
+		err := knownHostsCallback(hostname, remote, key)
+		if err == nil {
+			return nil
+		}
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) {
+			if len(keyErr.Want) == 0 {
+				return fmt.Errorf("ssh host key of %s with fingerprint %s not found in known_hosts file %s",
+					hostname, ssh.FingerprintSHA256(key), auth.KnownHostsFile)
+			}
+			wantFingerprints := make([]string, 0, len(keyErr.Want))
+			for _, want := range keyErr.Want {
+				wantFingerprints = append(wantFingerprints, fmt.Sprintf("%s (%s:%d)",
+					ssh.FingerprintSHA256(want.Key), want.Filename, want.Line))
+			}
+
+			return fmt.Errorf("ssh host key mismatch for %s : received fingerprint %s, known_hosts expected %s",
+				hostname, ssh.FingerprintSHA256(key), strings.Join(wantFingerprints, ", "))
+		}
+		var revokedErr *knownhosts.RevokedError
+		if errors.As(err, &revokedErr) {
+			return fmt.Errorf("ssh host key of %s with fingerprint %s is revoked in known_hosts file %s",
+				hostname, ssh.FingerprintSHA256(key), auth.KnownHostsFile)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// hostKeyFingerprintMatch compares a pinned fingerprint (SHA256:xxx or MD5 in hex format) with a host key.
+func hostKeyFingerprintMatch(fingerprint string, key ssh.PublicKey) bool {
+	if strings.HasPrefix(fingerprint, "SHA256:") {
+		return strings.TrimRight(fingerprint, "=") == ssh.FingerprintSHA256(key)
+	}
+
+	return strings.EqualFold(strings.TrimPrefix(fingerprint, "MD5:"), ssh.FingerprintLegacyMD5(key))
+}
+
+// sessionContext returns the context of session (context.Background if not set).
+func (j *NetconfObject) sessionContext() context.Context {
+	if j.ctx == nil {
+		return context.Background()
+	}
+
+	return j.ctx
+}
+
+// exec sends rpc on session and waits the reply until the end of the context of session.
+func (j *NetconfObject) exec(rpc string) (*netconf.RPCReply, error) {
+	return j.execContext(j.sessionContext(), rpc)
+}
+
+// execCleanup sends rpc on session with a context detached from the context of session
+// to clean up the session (clear, unlock, close) even after the end of its context.
+func (j *NetconfObject) execCleanup(rpc string) (*netconf.RPCReply, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), netconfCleanupTimeout)
+	defer cancel()
+
+	return j.execContext(ctx, rpc)
+}
+
+// execContext sends rpc on session and marks session broken if error isn't a netconf rpc-error.
+// At the end of ctx, the transport is closed to interrupt the rpc and the session is marked interrupted,
+// the device releases the lock and discards the uncommitted changes of the session with the end of it.
+func (j *NetconfObject) execContext(ctx context.Context, rpc string) (*netconf.RPCReply, error) {
+	if j.interrupted {
+		return nil, fmt.Errorf("netconf session interrupted, rpc %s not sent", rpcMethod(rpc))
 	}
+	if err := ctx.Err(); err != nil {
+		j.log().Trace("netconf rpc not sent", "rpc", rpcMethod(rpc), "error", err)
 
-	return config, errors.New("no credentials/keys available")
+		return nil, fmt.Errorf("rpc %s not sent : %w", rpcMethod(rpc), err)
+	}
+	type execResult struct {
+		reply *netconf.RPCReply
+		err   error
+	}
+	start := time.Now()
+	done := make(chan execResult, 1)
+	go func() {
+		reply, err := j.Session.Exec(netconf.RawMethod(rpc))
+		done <- execResult{reply: reply, err: err}
+	}()
+	var reply *netconf.RPCReply
+	var err error
+	select {
+	case result := <-done:
+		reply, err = result.reply, result.err
+	case <-ctx.Done():
+		// the reply can't be read anymore, the session is unusable
+		j.broken = true
+		j.interrupted = true
+		_ = j.Session.Transport.Close()
+		err = fmt.Errorf("rpc %s interrupted, netconf session closed : %w", rpcMethod(rpc), ctx.Err())
+	}
+	logger := j.log().With("rpc", rpcMethod(rpc), "duration", time.Since(start).String())
+	if err != nil {
+		var rpcErr *netconf.RPCError
+		if !errors.As(err, &rpcErr) {
+			j.broken = true
+		}
+		logger.Trace("netconf rpc failed", "request", rpc, "error", err)
+	} else {
+		logger.Trace("netconf rpc", "request", rpc, "reply", reply.RawReply)
+	}
+
+	return reply, err
+}
+
+// isLockDenied returns true if rpcErr is the error of a lock (or open private) denied by a lock
+// or changes of another session (retry can succeed), not a permanent error like permission denied.
+func isLockDenied(rpcErr *netconf.RPCError) bool {
+	if rpcErr.Tag == "lock-denied" {
+		return true
+	}
+	message := strings.ToLower(rpcErr.Message)
+
+	return strings.Contains(message, "database locked") || strings.Contains(message, "database modified")
+}
+
+// newLockDeniedError parses the rpc-error of lock (or open-configuration)
+// with a message like 'configuration database locked by:' followed by a line
+// 'user terminal pts/1 (pid 21773) on since ...' and the session-id in error-info.
+func newLockDeniedError(rpcErr *netconf.RPCError) *lockDeniedError {
+	lockErr := lockDeniedError{
+		message: strings.TrimSpace(rpcErr.Message),
+	}
+	var info lockDeniedInfo
+	if err := xml.Unmarshal([]byte("<rpc-error>"+rpcErr.Info+"</rpc-error>"), &info); err == nil {
+		lockErr.sessionID = strings.TrimSpace(info.SessionID)
+	}
+	lines := strings.Split(lockErr.message, "\n")
+	for i, line := range lines {
+		if !strings.HasSuffix(strings.TrimSpace(line), ":") || i+1 >= len(lines) {
+			continue
+		}
+		holder := strings.Fields(lines[i+1])
+		if len(holder) > 0 {
+			lockErr.user = holder[0]
+		}
+		if lockErr.sessionID == "" {
+			for j, field := range holder {
+				if field == "(pid" && j+1 < len(holder) {
+					lockErr.sessionID = strings.TrimSuffix(holder[j+1], ")")
+				}
+			}
+		}
+		lockErr.message = strings.TrimSuffix(strings.TrimSpace(line), ":")
+
+		break
+	}
+
+	return &lockErr
+}
+
+func (e *lockDeniedError) Error() string {
+	switch {
+	case e.user != "" && e.sessionID != "":
+		return fmt.Sprintf("%s by user %s (session-id %s)", e.message, e.user, e.sessionID)
+	case e.user != "":
+		return fmt.Sprintf("%s by user %s", e.message, e.user)
+	case e.sessionID != "":
+		return fmt.Sprintf("%s (session-id %s)", e.message, e.sessionID)
+	default:
+		return e.message
+	}
 }
 
 // gatherFacts gathers basic information about the device.
@@ -149,9 +631,8 @@ func (j *NetconfObject) gatherFacts() error {
 	if j == nil {
 		return errors.New("attempt to call GatherFacts on nil NetconfObject object")
 	}
-	s := j.Session
 	// Get info for get-system-information and populate SystemInformation Struct
-	val, err := s.Exec(netconf.RawMethod(rpcSystemInfo))
+	val, err := j.exec(rpcSystemInfo)
 	if err != nil {
 		return fmt.Errorf("failed to netconf get-system-information : %w", err)
 	}
@@ -175,7 +656,7 @@ func (j *NetconfObject) gatherFacts() error {
 // netconfCommand (show, execute) on Junos device.
 func (j *NetconfObject) netconfCommand(cmd string) (string, error) {
 	command := fmt.Sprintf(rpcCommand, cmd)
-	reply, err := j.Session.Exec(netconf.RawMethod(command))
+	reply, err := j.exec(command)
 	if err != nil {
 		return "", fmt.Errorf("failed to netconf command exec : %w", err)
 	}
@@ -195,7 +676,7 @@ func (j *NetconfObject) netconfCommand(cmd string) (string, error) {
 	return output.Config, nil
 }
 func (j *NetconfObject) netconfCommandXML(cmd string) (string, error) {
-	reply, err := j.Session.Exec(netconf.RawMethod(cmd))
+	reply, err := j.exec(cmd)
 	if err != nil {
 		return "", fmt.Errorf("failed to netconf xml command exec : %w", err)
 	}
@@ -210,11 +691,10 @@ func (j *NetconfObject) netconfCommandXML(cmd string) (string, error) {
 
 func (j *NetconfObject) netconfConfigSet(cmd []string) (string, error) {
 	command := fmt.Sprintf(rpcConfigStringSet, strings.Join(cmd, "\n"))
-	reply, err := j.Session.Exec(netconf.RawMethod(command))
+	reply, err := j.exec(command)
 	if err != nil {
 		return "", fmt.Errorf("failed to netconf set/delete command exec : %w", err)
 	}
-	// logFile("netconfConfigSet.Reply:" + reply.RawReply)
 	message := ""
 	if reply.Errors != nil {
 		for _, m := range reply.Errors {
@@ -228,21 +708,24 @@ func (j *NetconfObject) netconfConfigSet(cmd []string) (string, error) {
 }
 
 // netConfConfigLock locks the candidate configuration.
-func (j *NetconfObject) netconfConfigLock() bool {
-	reply, err := j.Session.Exec(netconf.RawMethod(rpcCandidateLock))
+func (j *NetconfObject) netconfConfigLock() error {
+	_, err := j.exec(rpcCandidateLock)
 	if err != nil {
-		return false
-	}
-	if reply.Errors != nil {
-		return false
+		var rpcErr *netconf.RPCError
+		if errors.As(err, &rpcErr) && isLockDenied(rpcErr) {
+			return newLockDeniedError(rpcErr)
+		}
+
+		return fmt.Errorf("failed to netconf config lock : %w", err)
 	}
+	j.locked = true
 
-	return true
+	return nil
 }
 
 // Unlock unlocks the candidate configuration.
 func (j *NetconfObject) netconfConfigUnlock() error {
-	reply, err := j.Session.Exec(netconf.RawMethod(rpcCandidateUnlock))
+	reply, err := j.execCleanup(rpcCandidateUnlock)
 	if err != nil {
 		return fmt.Errorf("failed to netconf config unlock : %w", err)
 	}
@@ -251,11 +734,13 @@ func (j *NetconfObject) netconfConfigUnlock() error {
 			return errors.New(m.Message)
 		}
 	}
+	j.locked = false
+	j.releaseCommit()
 
 	return nil
 }
 func (j *NetconfObject) netconfConfigClear() error {
-	reply, err := j.Session.Exec(netconf.RawMethod(rpcClearCandidate))
+	reply, err := j.execCleanup(rpcClearCandidate)
 	if err != nil {
 		return fmt.Errorf("failed to netconf config clear : %w", err)
 	}
@@ -268,10 +753,88 @@ func (j *NetconfObject) netconfConfigClear() error {
 	return nil
 }
 
+// netconfConfigOpenPrivate opens a private candidate configuration for this session.
+func (j *NetconfObject) netconfConfigOpenPrivate() error {
+	reply, err := j.exec(rpcOpenPrivate)
+	if err != nil {
+		var rpcErr *netconf.RPCError
+		if errors.As(err, &rpcErr) && isLockDenied(rpcErr) {
+			return newLockDeniedError(rpcErr)
+		}
+
+		return fmt.Errorf("failed to netconf open private configuration : %w", err)
+	}
+	if reply.Errors != nil {
+		for _, m := range reply.Errors {
+			if m.Severity != warningSeverity {
+				return errors.New(m.Message)
+			}
+		}
+	}
+	j.private = true
+
+	return nil
+}
+
+// netconfConfigClose closes the private candidate configuration and discards uncommitted changes.
+func (j *NetconfObject) netconfConfigClose() error {
+	reply, err := j.execCleanup(rpcCloseConfig)
+	if err != nil {
+		return fmt.Errorf("failed to netconf close configuration : %w", err)
+	}
+	if reply.Errors != nil {
+		for _, m := range reply.Errors {
+			return errors.New(m.Message)
+		}
+	}
+	j.private = false
+
+	return nil
+}
+
+// netconfConfigCompare returns the differences between the candidate configuration
+// and the committed configuration (like 'show | compare').
+func (j *NetconfObject) netconfConfigCompare() (string, error) {
+	reply, err := j.exec(rpcCompareConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to netconf compare configuration : %w", err)
+	}
+	if reply.Errors != nil {
+		for _, m := range reply.Errors {
+			if m.Severity != warningSeverity {
+				return "", errors.New(m.Message)
+			}
+		}
+	}
+	var compare struct {
+		Output string `xml:"configuration-information>configuration-output"`
+	}
+	if err := xml.Unmarshal([]byte("<compare>"+reply.Data+"</compare>"), &compare); err != nil {
+		return "", fmt.Errorf("failed to xml unmarshal reply of compare configuration : %w", err)
+	}
+
+	return strings.TrimSpace(compare.Output), nil
+}
+
+// netconfCommitCheck checks the candidate configuration without commit it.
+func (j *NetconfObject) netconfCommitCheck() (_warn []error, _err error) {
+	return j.netconfCommitRPC(rpcCommitCheck)
+}
+
 // netconfCommit commits the configuration.
 func (j *NetconfObject) netconfCommit(logMessage string) (_warn []error, _err error) {
+	return j.netconfCommitRPC(fmt.Sprintf(rpcCommit, logMessage))
+}
+
+// netconfCommitConfirmed commits the configuration with a rollback
+// after timeout (in minutes) if the commit is not confirmed by another commit.
+func (j *NetconfObject) netconfCommitConfirmed(logMessage string, timeout int) (_warn []error, _err error) {
+	return j.netconfCommitRPC(fmt.Sprintf(rpcCommitConfirmed, timeout, logMessage))
+}
+
+func (j *NetconfObject) netconfCommitRPC(rpc string) (_warn []error, _err error) {
 	var errs commitResults
-	reply, err := j.Session.Exec(netconf.RawMethod(fmt.Sprintf(rpcCommit, logMessage)))
+	reply, err := j.exec(rpc)
 	if err != nil {
 		return []error{}, fmt.Errorf("failed to netconf commit : %w", err)
 	}
@@ -298,14 +861,13 @@ func (j *NetconfObject) netconfCommit(logMessage string) (_warn []error, _err er
 			warnings := make([]error, 0)
 			for _, m := range errs.Errors {
 				if m.Severity != warningSeverity {
-					message := fmt.Sprintf("[%s]\n    %s\nError: %s",
-						strings.Trim(m.Path, "[\r\n]"),
-						strings.Trim(m.Element, "[\r\n]"),
-						strings.Trim(m.Message, "[\r\n]"))
-
-					return []error{}, errors.New(message)
+					return warnings, &commitRPCError{
+						path:    strings.Trim(m.Path, "[\r\n]"),
+						element: strings.Trim(m.Element, "[\r\n]"),
+						message: strings.Trim(m.Message, "[\r\n]"),
+					}
 				}
-				warnings = append(warnings, errors.New(m.Message))
+				warnings = append(warnings, errors.New(strings.Trim(m.Message, "[\r\n]")))
 			}
 
 			return warnings, nil
@@ -317,8 +879,21 @@ func (j *NetconfObject) netconfCommit(logMessage string) (_warn []error, _err er
 
 // Close disconnects our session to the device.
 func (j *NetconfObject) Close(sleepClosed int) error {
-	_, err := j.Session.Exec(netconf.RawMethod(rpcClose))
+	// the end of session releases the lock
+	j.releaseCommit()
+	if j.closed {
+		return nil
+	}
+	if j.interrupted {
+		// nothing can be sent, the transport is already closed
+		j.closed = true
+
+		return nil
+	}
+	_, err := j.execCleanup(rpcClose)
 	j.Session.Transport.Close()
+	j.broken = true
+	j.closed = true
 	if err != nil {
 		sleep(sleepClosed)
 
@@ -0,0 +1,286 @@
+package junos
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"terraform-provider-junos/junos/internal/netconftest"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func newTestHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate host key: %s", err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to convert host key: %s", err)
+	}
+
+	return key
+}
+
+func TestSetHostKeyCheck(t *testing.T) {
+	hostKey := newTestHostKey(t)
+	otherKey := newTestHostKey(t)
+	hostname := "192.0.2.1:830"
+	remote := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 830}
+	dir := t.TempDir()
+	writeKnownHosts := func(name string, lines ...string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+			t.Fatalf("failed to write known_hosts file: %s", err)
+		}
+
+		return path
+	}
+	knownHost := writeKnownHosts("known_hosts", knownhosts.Line([]string{hostname}, hostKey))
+	knownOther := writeKnownHosts("known_hosts_mismatch", knownhosts.Line([]string{hostname}, otherKey))
+	knownMissing := writeKnownHosts("known_hosts_missing", knownhosts.Line([]string{"192.0.2.2:830"}, hostKey))
+	knownRevoked := writeKnownHosts("known_hosts_revoked",
+		"@revoked * "+strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostKey))),
+		knownhosts.Line([]string{hostname}, hostKey))
+
+	cases := map[string]struct {
+		auth      netconfAuthMethod
+		configErr string
+		checkErr  string
+	}{
+		"insecure": {
+			auth: netconfAuthMethod{InsecureIgnoreHostKey: true},
+		},
+		"no_verification_method": {
+			auth:      netconfAuthMethod{},
+			configErr: "no method to verify the ssh host key",
+		},
+		"sha256_fingerprint": {
+			auth: netconfAuthMethod{HostKeyFingerprints: []string{ssh.FingerprintSHA256(hostKey)}},
+		},
+		"sha256_fingerprint_padded": {
+			auth: netconfAuthMethod{HostKeyFingerprints: []string{ssh.FingerprintSHA256(hostKey) + "="}},
+		},
+		"md5_fingerprint": {
+			auth: netconfAuthMethod{HostKeyFingerprints: []string{ssh.FingerprintLegacyMD5(hostKey)}},
+		},
+		"md5_fingerprint_prefixed_upper": {
+			auth: netconfAuthMethod{HostKeyFingerprints: []string{
+				"MD5:" + strings.ToUpper(ssh.FingerprintLegacyMD5(hostKey)),
+			}},
+		},
+		"fingerprint_mismatch": {
+			auth: netconfAuthMethod{HostKeyFingerprints: []string{
+				ssh.FingerprintSHA256(otherKey), ssh.FingerprintLegacyMD5(otherKey),
+			}},
+			checkErr: "doesn't match any pinned fingerprints",
+		},
+		"known_hosts": {
+			auth: netconfAuthMethod{KnownHostsFile: knownHost},
+		},
+		"known_hosts_mismatch": {
+			auth:     netconfAuthMethod{KnownHostsFile: knownOther},
+			checkErr: "ssh host key mismatch for " + hostname,
+		},
+		"known_hosts_missing_host": {
+			auth:     netconfAuthMethod{KnownHostsFile: knownMissing},
+			checkErr: "not found in known_hosts file",
+		},
+		"known_hosts_revoked": {
+			auth:     netconfAuthMethod{KnownHostsFile: knownRevoked},
+			checkErr: "is revoked in known_hosts file",
+		},
+		"known_hosts_mismatch_fingerprint_match": {
+			auth: netconfAuthMethod{
+				KnownHostsFile:      knownOther,
+				HostKeyFingerprints: []string{ssh.FingerprintSHA256(hostKey)},
+			},
+		},
+		"known_hosts_file_not_exist": {
+			auth:      netconfAuthMethod{KnownHostsFile: filepath.Join(dir, "not_exist")},
+			configErr: "failed to read known_hosts file",
+		},
+	}
+	for name, c := range cases {
+		auth := c.auth
+		var config ssh.ClientConfig
+		err := auth.setHostKeyCheck(&config)
+		if c.configErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.configErr) {
+				t.Errorf("%s: setHostKeyCheck() = %v, want error containing %q", name, err, c.configErr)
+			}
+
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: setHostKeyCheck() unexpected error: %s", name, err)
+
+			continue
+		}
+		err = config.HostKeyCallback(hostname, remote, hostKey)
+		switch {
+		case c.checkErr == "" && err != nil:
+			t.Errorf("%s: host key check unexpected error: %s", name, err)
+		case c.checkErr != "" && (err == nil || !strings.Contains(err.Error(), c.checkErr)):
+			t.Errorf("%s: host key check = %v, want error containing %q", name, err, c.checkErr)
+		}
+	}
+}
+
+// setTestSSHAuthSock sets SSH_AUTH_SOCK (restored at the end of test), an empty socket unsets the ssh-agent.
+func setTestSSHAuthSock(t *testing.T, socket string) {
+	t.Helper()
+	previous, set := os.LookupEnv("SSH_AUTH_SOCK")
+	t.Cleanup(func() {
+		if set {
+			os.Setenv("SSH_AUTH_SOCK", previous)
+		} else {
+			os.Unsetenv("SSH_AUTH_SOCK")
+		}
+	})
+	if socket == "" {
+		os.Unsetenv("SSH_AUTH_SOCK")
+	} else {
+		os.Setenv("SSH_AUTH_SOCK", socket)
+	}
+}
+
+// newTestSSHAgent serves an empty ssh-agent keyring on a unix socket and returns the socket path.
+func newTestSSHAgent(t *testing.T) string {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to listen on agent socket: %s", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	keyring := agent.NewKeyring()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_ = agent.ServeAgent(keyring, conn)
+				conn.Close()
+			}()
+		}
+	}()
+
+	return socket
+}
+
+func newTestPrivateKeyPEM(t *testing.T) string {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate private key: %s", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %s", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestGenSSHClientConfigAuthOrder(t *testing.T) {
+	agentSocket := newTestSSHAgent(t)
+	keyPEM := newTestPrivateKeyPEM(t)
+	cases := map[string]struct {
+		agent       bool
+		auth        netconfAuthMethod
+		wantMethods []string
+		wantAuth    []string
+	}{
+		"all_methods": {
+			agent: true,
+			auth:  netconfAuthMethod{Username: "test", PrivateKeyPEM: keyPEM, Password: "test"},
+			wantMethods: []string{
+				"publickey (ssh-agent)", "publickey (PEM private key)", "keyboard-interactive", "password",
+			},
+			wantAuth: []string{"ssh.publicKeyCallback", "ssh.KeyboardInteractiveChallenge", "ssh.passwordCallback"},
+		},
+		"agent_only": {
+			agent:       true,
+			auth:        netconfAuthMethod{Username: "test"},
+			wantMethods: []string{"publickey (ssh-agent)"},
+			wantAuth:    []string{"ssh.publicKeyCallback"},
+		},
+		"key_and_password": {
+			auth:        netconfAuthMethod{Username: "test", PrivateKeyPEM: keyPEM, Password: "test"},
+			wantMethods: []string{"publickey (PEM private key)", "keyboard-interactive", "password"},
+			wantAuth:    []string{"ssh.publicKeyCallback", "ssh.KeyboardInteractiveChallenge", "ssh.passwordCallback"},
+		},
+		"password_only": {
+			auth:        netconfAuthMethod{Username: "test", Password: "test"},
+			wantMethods: []string{"keyboard-interactive", "password"},
+			wantAuth:    []string{"ssh.KeyboardInteractiveChallenge", "ssh.passwordCallback"},
+		},
+	}
+	for name, c := range cases {
+		if c.agent {
+			setTestSSHAuthSock(t, agentSocket)
+		} else {
+			setTestSSHAuthSock(t, "")
+		}
+		auth := c.auth
+		auth.InsecureIgnoreHostKey = true
+		config, err := genSSHClientConfig(&auth)
+		auth.closeAgent()
+		if err != nil {
+			t.Errorf("%s: genSSHClientConfig() unexpected error: %s", name, err)
+
+			continue
+		}
+		if !reflect.DeepEqual(auth.methods, c.wantMethods) {
+			t.Errorf("%s: methods = %q, want %q", name, auth.methods, c.wantMethods)
+		}
+		authTypes := make([]string, 0, len(config.Auth))
+		for _, method := range config.Auth {
+			authTypes = append(authTypes, fmt.Sprintf("%T", method))
+		}
+		if !reflect.DeepEqual(authTypes, c.wantAuth) {
+			t.Errorf("%s: auth methods = %q, want %q", name, authTypes, c.wantAuth)
+		}
+	}
+
+	setTestSSHAuthSock(t, "")
+	if _, err := genSSHClientConfig(&netconfAuthMethod{Username: "test", InsecureIgnoreHostKey: true}); err == nil ||
+		!strings.Contains(err.Error(), "no credentials/keys available") {
+		t.Errorf("genSSHClientConfig() without credentials = %v, want a no credentials error", err)
+	}
+}
+
+func TestNetconfNewSessionMethodsTried(t *testing.T) {
+	setTestSSHAuthSock(t, "")
+	server, err := netconftest.NewServer("test", "test")
+	if err != nil {
+		t.Fatalf("failed to start netconf server: %s", err)
+	}
+	defer server.Close()
+
+	_, err = netconfNewSession(server.Addr, &netconfAuthMethod{
+		Username:              "test",
+		Password:              "wrong",
+		PrivateKeyPEM:         newTestPrivateKeyPEM(t),
+		InsecureIgnoreHostKey: true,
+	}, nil)
+	want := "(authentication methods tried : publickey (PEM private key), keyboard-interactive, password)"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("netconfNewSession() with no usable method = %v, want error containing %q", err, want)
+	}
+}
@@ -0,0 +1,61 @@
+package junos
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// planWarningsKey : key of the planWarnings in the context of a plan.
+type planWarningsKey struct{}
+
+// planWarnings : warnings collected during the plan of a resource
+// (CustomizeDiff can only return an error).
+type planWarnings struct {
+	mutex    sync.Mutex
+	warnings []*tfprotov5.Diagnostic
+}
+
+// addPlanWarning adds a warning to the diagnostics of the plan in progress with ctx,
+// returns false if ctx isn't the context of a plan.
+func addPlanWarning(ctx context.Context, summary, detail string) bool {
+	collector, ok := ctx.Value(planWarningsKey{}).(*planWarnings)
+	if !ok {
+		return false
+	}
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	collector.warnings = append(collector.warnings, &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  summary,
+		Detail:   detail,
+	})
+
+	return true
+}
+
+// planWarningsServer : provider server which returns the warnings added by addPlanWarning
+// with the diagnostics of PlanResourceChange.
+type planWarningsServer struct {
+	tfprotov5.ProviderServer
+}
+
+// GRPCProviderServer returns the provider server to serve the provider.
+func GRPCProviderServer() tfprotov5.ProviderServer {
+	return &planWarningsServer{ProviderServer: schema.NewGRPCProviderServer(Provider())}
+}
+
+func (s *planWarningsServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (
+	*tfprotov5.PlanResourceChangeResponse, error) {
+	collector := &planWarnings{}
+	resp, err := s.ProviderServer.PlanResourceChange(context.WithValue(ctx, planWarningsKey{}, collector), req)
+	if resp != nil {
+		collector.mutex.Lock()
+		resp.Diagnostics = append(resp.Diagnostics, collector.warnings...)
+		collector.mutex.Unlock()
+	}
+
+	return resp, err
+}
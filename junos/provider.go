@@ -2,10 +2,11 @@ package junos
 
 import (
 	"context"
-	"sync"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -32,17 +33,13 @@ const (
 	dynamicDB      = "dynamic-db"
 )
 
-var (
-	mutex = &sync.Mutex{}
-)
-
 // Provider junos for terraform.
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"ip": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_HOST", nil),
 			},
 			"port": {
@@ -80,6 +77,26 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_GROUP_INTERFACE_DELETE", nil),
 			},
+			"logical_system": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_LOGICAL_SYSTEM", ""),
+				ConflictsWith:    []string{"tenant"},
+				ValidateDiagFunc: validateNameObjectJunos([]string{}, 63),
+			},
+			"tenant": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_TENANT", ""),
+				ConflictsWith:    []string{"logical_system"},
+				ValidateDiagFunc: validateNameObjectJunos([]string{}, 63),
+			},
+			"config_group": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_CONFIG_GROUP", ""),
+				ValidateDiagFunc: validateNameObjectJunos([]string{}, 63),
+			},
 			"cmd_sleep_short": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -90,98 +107,421 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_SLEEP_LOCK", 10),
 			},
+			"lock_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_LOCK_TIMEOUT", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"config_database_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_CONFIG_DATABASE_MODE", "exclusive"),
+				ValidateFunc: validation.StringInSlice([]string{"exclusive", "private"}, false),
+			},
+			"commit_confirmed": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_COMMIT_CONFIRMED", 0),
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"commit_confirmed_health_check": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_COMMIT_CONFIRMED_HEALTH_CHECK", nil),
+			},
+			"commit_check_on_plan": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_COMMIT_CHECK_ON_PLAN", false),
+			},
+			"diff_on_plan": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_DIFF_ON_PLAN", false),
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_ADOPT_EXISTING", false),
+			},
+			"diff_audit_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_DIFF_AUDIT_FILE", ""),
+			},
+			"batch_commit": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_BATCH_COMMIT", false),
+			},
+			"batch_commit_idle_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_BATCH_COMMIT_IDLE_TIMEOUT", 5),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			"ssh_sleep_closed": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_SLEEP_SSH_CLOSED", 0),
 			},
+			"ssh_known_hosts_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_SSH_KNOWN_HOSTS_FILE", nil),
+			},
+			"ssh_host_key_fingerprints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ssh_insecure_ignore_host_key": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_SSH_INSECURE_IGNORE_HOST_KEY", false),
+			},
+			"bastion": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      22,
+							ValidateFunc: validation.IntBetween(1, 65535),
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"password": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"sshkey_pem": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"sshkeyfile": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"keypass": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ssh_known_hosts_file": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ssh_host_key_fingerprints": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"ssh_insecure_ignore_host_key": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"device": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"ip": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(1, 65535),
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"password": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"sshkey_pem": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"sshkeyfile": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"keypass": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ssh_known_hosts_file": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ssh_host_key_fingerprints": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"ssh_insecure_ignore_host_key": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"logical_system": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validateNameObjectJunos([]string{}, 63),
+						},
+						"tenant": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validateNameObjectJunos([]string{}, 63),
+						},
+					},
+				},
+			},
+			"ssh_pool_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_SSH_POOL_SIZE", 10),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"config_snapshot_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_CONFIG_SNAPSHOT_TTL", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"read_parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_READ_PARALLELISM", 10),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"transport_retry_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_TRANSPORT_RETRY_COUNT", 2),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"transport_retry_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_TRANSPORT_RETRY_BACKOFF", 2),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			"debug_netconf_log_path": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_LOG_PATH", ""),
 			},
 		},
-		ResourcesMap: map[string]*schema.Resource{
-			"junos_aggregate_route":                                      resourceAggregateRoute(),
-			"junos_application":                                          resourceApplication(),
-			"junos_application_set":                                      resourceApplicationSet(),
-			"junos_bgp_group":                                            resourceBgpGroup(),
-			"junos_bgp_neighbor":                                         resourceBgpNeighbor(),
-			"junos_firewall_filter":                                      resourceFirewallFilter(),
-			"junos_firewall_policer":                                     resourceFirewallPolicer(),
-			"junos_interface":                                            resourceInterface(),
-			"junos_interface_logical":                                    resourceInterfaceLogical(),
-			"junos_interface_physical":                                   resourceInterfacePhysical(),
-			"junos_interface_st0_unit":                                   resourceInterfaceSt0Unit(),
-			"junos_ospf_area":                                            resourceOspfArea(),
-			"junos_policyoptions_as_path":                                resourcePolicyoptionsAsPath(),
-			"junos_policyoptions_as_path_group":                          resourcePolicyoptionsAsPathGroup(),
-			"junos_policyoptions_community":                              resourcePolicyoptionsCommunity(),
-			"junos_policyoptions_policy_statement":                       resourcePolicyoptionsPolicyStatement(),
-			"junos_policyoptions_prefix_list":                            resourcePolicyoptionsPrefixList(),
-			"junos_rib_group":                                            resourceRibGroup(),
-			"junos_routing_instance":                                     resourceRoutingInstance(),
-			"junos_routing_options":                                      resourceRoutingOptions(),
-			"junos_security":                                             resourceSecurity(),
-			"junos_security_ike_gateway":                                 resourceIkeGateway(),
-			"junos_security_ike_policy":                                  resourceIkePolicy(),
-			"junos_security_ike_proposal":                                resourceIkeProposal(),
-			"junos_security_ipsec_policy":                                resourceIpsecPolicy(),
-			"junos_security_ipsec_proposal":                              resourceIpsecProposal(),
-			"junos_security_ipsec_vpn":                                   resourceIpsecVpn(),
-			"junos_security_log_stream":                                  resourceSecurityLogStream(),
-			"junos_security_nat_destination":                             resourceSecurityNatDestination(),
-			"junos_security_nat_destination_pool":                        resourceSecurityNatDestinationPool(),
-			"junos_security_nat_source":                                  resourceSecurityNatSource(),
-			"junos_security_nat_source_pool":                             resourceSecurityNatSourcePool(),
-			"junos_security_nat_static":                                  resourceSecurityNatStatic(),
-			"junos_security_policy":                                      resourceSecurityPolicy(),
-			"junos_security_policy_tunnel_pair_policy":                   resourceSecurityPolicyTunnelPairPolicy(),
-			"junos_security_screen":                                      resourceSecurityScreen(),
-			"junos_security_screen_whitelist":                            resourceSecurityScreenWhiteList(),
-			"junos_security_utm_custom_url_category":                     resourceSecurityUtmCustomURLCategory(),
-			"junos_security_utm_custom_url_pattern":                      resourceSecurityUtmCustomURLPattern(),
-			"junos_security_utm_policy":                                  resourceSecurityUtmPolicy(),
-			"junos_security_utm_profile_web_filtering_juniper_enhanced":  resourceSecurityUtmProfileWebFilteringEnhanced(),
-			"junos_security_utm_profile_web_filtering_juniper_local":     resourceSecurityUtmProfileWebFilteringLocal(),
-			"junos_security_utm_profile_web_filtering_websense_redirect": resourceSecurityUtmProfileWebFilteringWebsense(),
-			"junos_security_zone":                                        resourceSecurityZone(),
-			"junos_static_route":                                         resourceStaticRoute(),
-			"junos_system":                                               resourceSystem(),
-			"junos_system_login_class":                                   resourceSystemLoginClass(),
-			"junos_system_login_user":                                    resourceSystemLoginUser(),
-			"junos_system_ntp_server":                                    resourceSystemNtpServer(),
-			"junos_system_radius_server":                                 resourceSystemRadiusServer(),
-			"junos_system_root_authentication":                           resourceSystemRootAuthentication(),
-			"junos_system_syslog_file":                                   resourceSystemSyslogFile(),
-			"junos_system_syslog_host":                                   resourceSystemSyslogHost(),
-			"junos_vlan":                                                 resourceVlan(),
-		},
-		DataSourcesMap: map[string]*schema.Resource{
-			"junos_interface":          dataSourceInterface(),
-			"junos_interface_logical":  dataSourceInterfaceLogical(),
-			"junos_interface_physical": dataSourceInterfacePhysical(),
-			"junos_system_information": dataSourceSystemInformation(),
-		},
+		ResourcesMap: resourcesWithDevice(resourcesWithLogicalSystem(
+			resourcesWithDiff(resourcesWithAdopt(resourcesWithTimeouts(map[string]*schema.Resource{
+				"junos_aggregate_route":                                      resourceAggregateRoute(),
+				"junos_application":                                          resourceApplication(),
+				"junos_application_set":                                      resourceApplicationSet(),
+				"junos_bgp_group":                                            resourceBgpGroup(),
+				"junos_bgp_neighbor":                                         resourceBgpNeighbor(),
+				"junos_firewall_filter":                                      resourceFirewallFilter(),
+				"junos_firewall_policer":                                     resourceFirewallPolicer(),
+				"junos_interface":                                            resourceInterface(),
+				"junos_interface_logical":                                    resourceInterfaceLogical(),
+				"junos_interface_physical":                                   resourceInterfacePhysical(),
+				"junos_interface_st0_unit":                                   resourceInterfaceSt0Unit(),
+				"junos_ospf_area":                                            resourceOspfArea(),
+				"junos_policyoptions_as_path":                                resourcePolicyoptionsAsPath(),
+				"junos_policyoptions_as_path_group":                          resourcePolicyoptionsAsPathGroup(),
+				"junos_policyoptions_community":                              resourcePolicyoptionsCommunity(),
+				"junos_policyoptions_policy_statement":                       resourcePolicyoptionsPolicyStatement(),
+				"junos_policyoptions_prefix_list":                            resourcePolicyoptionsPrefixList(),
+				"junos_raw_config":                                           resourceRawConfig(),
+				"junos_rib_group":                                            resourceRibGroup(),
+				"junos_routing_instance":                                     resourceRoutingInstance(),
+				"junos_routing_options":                                      resourceRoutingOptions(),
+				"junos_security":                                             resourceSecurity(),
+				"junos_security_ike_gateway":                                 resourceIkeGateway(),
+				"junos_security_ike_policy":                                  resourceIkePolicy(),
+				"junos_security_ike_proposal":                                resourceIkeProposal(),
+				"junos_security_ipsec_policy":                                resourceIpsecPolicy(),
+				"junos_security_ipsec_proposal":                              resourceIpsecProposal(),
+				"junos_security_ipsec_vpn":                                   resourceIpsecVpn(),
+				"junos_security_log_stream":                                  resourceSecurityLogStream(),
+				"junos_security_nat_destination":                             resourceSecurityNatDestination(),
+				"junos_security_nat_destination_pool":                        resourceSecurityNatDestinationPool(),
+				"junos_security_nat_source":                                  resourceSecurityNatSource(),
+				"junos_security_nat_source_pool":                             resourceSecurityNatSourcePool(),
+				"junos_security_nat_static":                                  resourceSecurityNatStatic(),
+				"junos_security_policy":                                      resourceSecurityPolicy(),
+				"junos_security_policy_tunnel_pair_policy":                   resourceSecurityPolicyTunnelPairPolicy(),
+				"junos_security_screen":                                      resourceSecurityScreen(),
+				"junos_security_screen_whitelist":                            resourceSecurityScreenWhiteList(),
+				"junos_security_utm_custom_url_category":                     resourceSecurityUtmCustomURLCategory(),
+				"junos_security_utm_custom_url_pattern":                      resourceSecurityUtmCustomURLPattern(),
+				"junos_security_utm_policy":                                  resourceSecurityUtmPolicy(),
+				"junos_security_utm_profile_web_filtering_juniper_enhanced":  resourceSecurityUtmProfileWebFilteringEnhanced(),
+				"junos_security_utm_profile_web_filtering_juniper_local":     resourceSecurityUtmProfileWebFilteringLocal(),
+				"junos_security_utm_profile_web_filtering_websense_redirect": resourceSecurityUtmProfileWebFilteringWebsense(),
+				"junos_security_zone":                                        resourceSecurityZone(),
+				"junos_static_route":                                         resourceStaticRoute(),
+				"junos_system":                                               resourceSystem(),
+				"junos_system_login_class":                                   resourceSystemLoginClass(),
+				"junos_system_login_user":                                    resourceSystemLoginUser(),
+				"junos_system_ntp_server":                                    resourceSystemNtpServer(),
+				"junos_system_radius_server":                                 resourceSystemRadiusServer(),
+				"junos_system_root_authentication":                           resourceSystemRootAuthentication(),
+				"junos_system_syslog_file":                                   resourceSystemSyslogFile(),
+				"junos_system_syslog_host":                                   resourceSystemSyslogHost(),
+				"junos_vlan":                                                 resourceVlan(),
+			}))))),
+		DataSourcesMap: dataSourcesWithDevice(map[string]*schema.Resource{
+			"junos_command":              dataSourceCommand(),
+			"junos_config_group_orphans": dataSourceConfigGroupOrphans(),
+			"junos_interface":            dataSourceInterface(),
+			"junos_interface_logical":    dataSourceInterfaceLogical(),
+			"junos_interface_physical":   dataSourceInterfacePhysical(),
+			"junos_system_information":   dataSourceSystemInformation(),
+		}),
 		ConfigureContextFunc: configureProvider,
 	}
 }
 
 func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	config := Config{
-		junosIP:                  d.Get("ip").(string),
-		junosPort:                d.Get("port").(int),
-		junosUserName:            d.Get("username").(string),
-		junosPassword:            d.Get("password").(string),
-		junosSSHKeyPEM:           d.Get("sshkey_pem").(string),
-		junosSSHKeyFile:          d.Get("sshkeyfile").(string),
-		junosKeyPass:             d.Get("keypass").(string),
-		junosGroupIntDel:         d.Get("group_interface_delete").(string),
-		junosCmdSleepShort:       d.Get("cmd_sleep_short").(int),
-		junosCmdSleepLock:        d.Get("cmd_sleep_lock").(int),
-		junosSSHSleepClosed:      d.Get("ssh_sleep_closed").(int),
-		junosDebugNetconfLogPath: d.Get("debug_netconf_log_path").(string),
+		junosSSHInsecure:          d.Get("ssh_insecure_ignore_host_key").(bool),
+		junosCommitCheckPlan:      d.Get("commit_check_on_plan").(bool),
+		junosConfigPrivate:        d.Get("config_database_mode").(string) == "private",
+		junosBatchCommit:          d.Get("batch_commit").(bool),
+		junosDiffPlan:             d.Get("diff_on_plan").(bool),
+		junosAdoptExisting:        d.Get("adopt_existing").(bool),
+		junosIP:                   d.Get("ip").(string),
+		junosPort:                 d.Get("port").(int),
+		junosUserName:             d.Get("username").(string),
+		junosPassword:             d.Get("password").(string),
+		junosSSHKeyPEM:            d.Get("sshkey_pem").(string),
+		junosSSHKeyFile:           d.Get("sshkeyfile").(string),
+		junosKeyPass:              d.Get("keypass").(string),
+		junosGroupIntDel:          d.Get("group_interface_delete").(string),
+		junosLogicalSystem:        d.Get("logical_system").(string),
+		junosTenant:               d.Get("tenant").(string),
+		junosConfigGroup:          d.Get("config_group").(string),
+		junosCmdSleepShort:        d.Get("cmd_sleep_short").(int),
+		junosCmdSleepLock:         d.Get("cmd_sleep_lock").(int),
+		junosLockTimeout:          d.Get("lock_timeout").(int),
+		junosBatchCommitIdle:      d.Get("batch_commit_idle_timeout").(int),
+		junosSSHSleepClosed:       d.Get("ssh_sleep_closed").(int),
+		junosSSHPoolSize:          d.Get("ssh_pool_size").(int),
+		junosRetryCount:           d.Get("transport_retry_count").(int),
+		junosRetryBackoff:         d.Get("transport_retry_backoff").(int),
+		junosConfigSnapshotTTL:    d.Get("config_snapshot_ttl").(int),
+		junosReadParallelism:      d.Get("read_parallelism").(int),
+		junosDebugNetconfLogPath:  d.Get("debug_netconf_log_path").(string),
+		junosSSHKnownHosts:        d.Get("ssh_known_hosts_file").(string),
+		junosCommitConfirmed:      d.Get("commit_confirmed").(int),
+		junosCommitConfirmedCheck: d.Get("commit_confirmed_health_check").(string),
+		junosDiffAuditFile:        d.Get("diff_audit_file").(string),
+	}
+	for _, v := range d.Get("ssh_host_key_fingerprints").([]interface{}) {
+		config.junosSSHFingerprints = append(config.junosSSHFingerprints, v.(string))
+	}
+	for _, v := range d.Get("bastion").([]interface{}) {
+		bastion := v.(map[string]interface{})
+		config.junosBastion = &netconfBastion{
+			Host: bastion["host"].(string) + ":" + strconv.Itoa(bastion["port"].(int)),
+			Auth: netconfAuthMethod{
+				InsecureIgnoreHostKey: bastion["ssh_insecure_ignore_host_key"].(bool),
+				Username:              bastion["username"].(string),
+				Password:              bastion["password"].(string),
+				PrivateKeyPEM:         bastion["sshkey_pem"].(string),
+				PrivateKeyFile:        bastion["sshkeyfile"].(string),
+				Passphrase:            bastion["keypass"].(string),
+				KnownHostsFile:        bastion["ssh_known_hosts_file"].(string),
+			},
+		}
+		if config.junosBastion.Auth.Username == "" {
+			config.junosBastion.Auth.Username = config.junosUserName
+		}
+		for _, v2 := range bastion["ssh_host_key_fingerprints"].([]interface{}) {
+			config.junosBastion.Auth.HostKeyFingerprints = append(config.junosBastion.Auth.HostKeyFingerprints,
+				v2.(string))
+		}
+	}
+	for _, v := range d.Get("device").([]interface{}) {
+		device := v.(map[string]interface{})
+		devConfig := deviceConfig{
+			sshInsecure:   device["ssh_insecure_ignore_host_key"].(bool),
+			port:          device["port"].(int),
+			name:          device["name"].(string),
+			ip:            device["ip"].(string),
+			userName:      device["username"].(string),
+			password:      device["password"].(string),
+			sshKeyPEM:     device["sshkey_pem"].(string),
+			sshKeyFile:    device["sshkeyfile"].(string),
+			keyPass:       device["keypass"].(string),
+			sshKnownHosts: device["ssh_known_hosts_file"].(string),
+			logicalSystem: device["logical_system"].(string),
+			tenant:        device["tenant"].(string),
+		}
+		if devConfig.logicalSystem != "" && devConfig.tenant != "" {
+			return nil, diag.Errorf("logical_system and tenant can't be set together in device %q", devConfig.name)
+		}
+		for _, v2 := range device["ssh_host_key_fingerprints"].([]interface{}) {
+			devConfig.sshFingerprints = append(devConfig.sshFingerprints, v2.(string))
+		}
+		config.junosDevices = append(config.junosDevices, devConfig)
+	}
+	if config.junosConfigGroup != "" && config.junosGroupIntDel != "" {
+		return nil, diag.Errorf("group_interface_delete can't be used with config_group " +
+			"(apply-groups is not allowed inside a configuration group)")
+	}
+	if config.junosIP == "" && len(config.junosDevices) == 0 {
+		return nil, diag.Errorf("one of ip or device must be set in provider configuration")
+	}
+
+	sess, diags := config.Session()
+	if sess != nil {
+		// commit the changes waiting for a batch and close sessions kept for reuse
+		// when Terraform stops the provider
+		if stopCtx, ok := schema.StopContext(ctx); ok {
+			go func() {
+				<-stopCtx.Done()
+				if sess.commitBatcher != nil {
+					sess.commitBatcher.flush(sess)
+				}
+				for _, devSess := range sess.devices {
+					if devSess.commitBatcher != nil {
+						devSess.commitBatcher.flush(devSess)
+					}
+				}
+				if sess.netconfPool != nil {
+					sess.netconfPool.close(sess.junosSleepSSHClosed)
+				}
+				for _, devSess := range sess.devices {
+					if devSess.netconfPool != nil {
+						devSess.netconfPool.close(devSess.junosSleepSSHClosed)
+					}
+				}
+			}()
+		}
 	}
 
-	return config.Session()
+	return sess, diags
 }
@@ -2,8 +2,11 @@ package junos_test
 
 import (
 	"context"
+	"log"
+	"net"
 	"os"
 	"terraform-provider-junos/junos"
+	"terraform-provider-junos/junos/internal/netconftest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,6 +22,38 @@ var (
 
 const defaultInterfaceTestAcc = "ge-0/0/3"
 
+// export TESTACC_FAKE_NETCONF not empty to run acceptance tests against a local fake netconf server
+// instead of a Junos device (JUNOS_HOST, JUNOS_PORT, JUNOS_USERNAME and JUNOS_PASSWORD are overridden).
+// The fake server only stores and displays set lines without check of the Junos syntax.
+func TestMain(m *testing.M) {
+	if os.Getenv("TESTACC_FAKE_NETCONF") == "" {
+		os.Exit(m.Run())
+	}
+	server, err := netconftest.NewServer("testacc", "testacc")
+	if err != nil {
+		log.Fatalf("failed to start fake netconf server: %s", err)
+	}
+	server.Interfaces = []string{"ge-0/0/0", "ge-0/0/1", "ge-0/0/2", defaultInterfaceTestAcc}
+	host, port, err := net.SplitHostPort(server.Addr)
+	if err != nil {
+		log.Fatalf("failed to split address of fake netconf server: %s", err)
+	}
+	for k, v := range map[string]string{
+		"JUNOS_HOST":                         host,
+		"JUNOS_PORT":                         port,
+		"JUNOS_USERNAME":                     "testacc",
+		"JUNOS_PASSWORD":                     "testacc",
+		"JUNOS_KEYPEM":                       "",
+		"JUNOS_KEYFILE":                      "",
+		"JUNOS_SSH_INSECURE_IGNORE_HOST_KEY": "true",
+	} {
+		os.Setenv(k, v)
+	}
+	code := m.Run()
+	_ = server.Close()
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := junos.Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
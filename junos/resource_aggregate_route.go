@@ -31,8 +31,9 @@ func resourceAggregateRoute() *schema.Resource {
 		ReadContext:   resourceAggregateRouteRead,
 		UpdateContext: resourceAggregateRouteUpdate,
 		DeleteContext: resourceAggregateRouteDelete,
+		CustomizeDiff: resourceAggregateRouteCommitCheck,
 		Importer: &schema.ResourceImporter{
-			State: resourceAggregateRouteImport,
+			StateContext: resourceAggregateRouteImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"destination": {
@@ -96,50 +97,63 @@ func resourceAggregateRoute() *schema.Resource {
 
 func resourceAggregateRouteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if d.Get("routing_instance").(string) != defaultWord {
 		instanceExists, err := checkRoutingInstanceExists(d.Get("routing_instance").(string), m, jnprSess)
 		if err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 		if !instanceExists {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string)))
+			return append(diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string))),
+				diag.FromErr(clearErr)...)
 		}
 	}
 	aggregateRouteExists, err := checkAggregateRouteExists(
 		d.Get("destination").(string), d.Get("routing_instance").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
+	var diagWarns diag.Diagnostics
 	if aggregateRouteExists {
-		sess.configClear(jnprSess)
+		existsErr := fmt.Errorf("aggregate route %v already exists on table %s",
+			d.Get("destination").(string), d.Get("routing_instance").(string))
+		if !sess.adoptExisting(d) {
+			clearErr := sess.configClear(jnprSess)
+
+			return append(diag.FromErr(existsErr), diag.FromErr(clearErr)...)
+		}
+		if err := delAggregateRoute(d.Get("destination").(string), d.Get("routing_instance").(string),
+			m, jnprSess); err != nil {
+			clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("aggregate route %v already exists on table %s",
-			d.Get("destination").(string), d.Get("routing_instance").(string)))
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+		}
+		diagWarns = append(diagWarns, adoptedWarning("junos_aggregate_route", existsErr))
 	}
 	if err := setAggregateRoute(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
-	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("create resource junos_aggregate_route", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	aggregateRouteExists, err = checkAggregateRouteExists(
 		d.Get("destination").(string), d.Get("routing_instance").(string), m, jnprSess)
@@ -158,7 +172,7 @@ func resourceAggregateRouteCreate(ctx context.Context, d *schema.ResourceData, m
 }
 func resourceAggregateRouteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -168,10 +182,8 @@ func resourceAggregateRouteRead(ctx context.Context, d *schema.ResourceData, m i
 }
 func resourceAggregateRouteReadWJnprSess(
 	d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
-	mutex.Lock()
 	aggregateRouteOptions, err := readAggregateRoute(d.Get("destination").(string), d.Get("routing_instance").(string),
 		m, jnprSess)
-	mutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -186,30 +198,32 @@ func resourceAggregateRouteReadWJnprSess(
 func resourceAggregateRouteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.Partial(true)
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delAggregateRouteOpts(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 
 	if err := setAggregateRoute(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("update resource junos_aggregate_route", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	d.Partial(false)
 
@@ -217,32 +231,35 @@ func resourceAggregateRouteUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 func resourceAggregateRouteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delAggregateRoute(d.Get("destination").(string), d.Get("routing_instance").(string),
 		m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("delete resource junos_aggregate_route", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 
 	return diagWarns
 }
-func resourceAggregateRouteImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceAggregateRouteImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -270,6 +287,18 @@ func resourceAggregateRouteImport(d *schema.ResourceData, m interface{}) ([]*sch
 
 	return result, nil
 }
+func resourceAggregateRouteCommitCheck(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	return customizeDiffCommitCheck(ctx, "junos_aggregate_route", resourceAggregateRoute, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			if d.Id() != "" {
+				if err := delAggregateRouteOpts(d, m, jnprSess); err != nil {
+					return err
+				}
+			}
+
+			return setAggregateRoute(d, m, jnprSess)
+		})
+}
 
 func checkAggregateRouteExists(destination string, instance string, m interface{},
 	jnprSess *NetconfObject) (bool, error) {
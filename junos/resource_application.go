@@ -22,8 +22,9 @@ func resourceApplication() *schema.Resource {
 		ReadContext:   resourceApplicationRead,
 		UpdateContext: resourceApplicationUpdate,
 		DeleteContext: resourceApplicationDelete,
+		CustomizeDiff: resourceApplicationCommitCheck,
 		Importer: &schema.ResourceImporter{
-			State: resourceApplicationImport,
+			StateContext: resourceApplicationImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -50,35 +51,46 @@ func resourceApplication() *schema.Resource {
 
 func resourceApplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	appExists, err := checkApplicationExists(d.Get("name").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
+	var diagWarns diag.Diagnostics
 	if appExists {
-		sess.configClear(jnprSess)
+		existsErr := fmt.Errorf("application %v already exists", d.Get("name").(string))
+		if !sess.adoptExisting(d) {
+			clearErr := sess.configClear(jnprSess)
+
+			return append(diag.FromErr(existsErr), diag.FromErr(clearErr)...)
+		}
+		if err := delApplication(d, m, jnprSess); err != nil {
+			clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("application %v already exists", d.Get("name").(string)))
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+		}
+		diagWarns = append(diagWarns, adoptedWarning("junos_application", existsErr))
 	}
 	if err := setApplication(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
-	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("create resource junos_application", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	appExists, err = checkApplicationExists(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -95,7 +107,7 @@ func resourceApplicationCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 func resourceApplicationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -104,9 +116,7 @@ func resourceApplicationRead(ctx context.Context, d *schema.ResourceData, m inte
 	return resourceApplicationReadWJnprSess(d, m, jnprSess)
 }
 func resourceApplicationReadWJnprSess(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
-	mutex.Lock()
 	applicationOptions, err := readApplication(d.Get("name").(string), m, jnprSess)
-	mutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -121,29 +131,31 @@ func resourceApplicationReadWJnprSess(d *schema.ResourceData, m interface{}, jnp
 func resourceApplicationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.Partial(true)
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delApplication(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if err := setApplication(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("update resource junos_application", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	d.Partial(false)
 
@@ -151,31 +163,34 @@ func resourceApplicationUpdate(ctx context.Context, d *schema.ResourceData, m in
 }
 func resourceApplicationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delApplication(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("delete resource junos_application", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 
 	return diagWarns
 }
-func resourceApplicationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceApplicationImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -197,6 +212,18 @@ func resourceApplicationImport(d *schema.ResourceData, m interface{}) ([]*schema
 
 	return result, nil
 }
+func resourceApplicationCommitCheck(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	return customizeDiffCommitCheck(ctx, "junos_application", resourceApplication, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			if d.Id() != "" {
+				if err := delApplication(d, m, jnprSess); err != nil {
+					return err
+				}
+			}
+
+			return setApplication(d, m, jnprSess)
+		})
+}
 
 func checkApplicationExists(application string, m interface{}, jnprSess *NetconfObject) (bool, error) {
 	sess := m.(*Session)
@@ -20,8 +20,9 @@ func resourceApplicationSet() *schema.Resource {
 		ReadContext:   resourceApplicationSetRead,
 		UpdateContext: resourceApplicationSetUpdate,
 		DeleteContext: resourceApplicationSetDelete,
+		CustomizeDiff: resourceApplicationSetCommitCheck,
 		Importer: &schema.ResourceImporter{
-			State: resourceApplicationSetImport,
+			StateContext: resourceApplicationSetImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -42,35 +43,46 @@ func resourceApplicationSet() *schema.Resource {
 
 func resourceApplicationSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	appSetExists, err := checkApplicationSetExists(d.Get("name").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
+	var diagWarns diag.Diagnostics
 	if appSetExists {
-		sess.configClear(jnprSess)
+		existsErr := fmt.Errorf("application-set %v already exists", d.Get("name").(string))
+		if !sess.adoptExisting(d) {
+			clearErr := sess.configClear(jnprSess)
+
+			return append(diag.FromErr(existsErr), diag.FromErr(clearErr)...)
+		}
+		if err := delApplicationSet(d, m, jnprSess); err != nil {
+			clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("application-set %v already exists", d.Get("name").(string)))
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+		}
+		diagWarns = append(diagWarns, adoptedWarning("junos_application_set", existsErr))
 	}
 	if err := setApplicationSet(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
-	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("create resource junos_application_set", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	appSetExists, err = checkApplicationSetExists(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -87,7 +99,7 @@ func resourceApplicationSetCreate(ctx context.Context, d *schema.ResourceData, m
 }
 func resourceApplicationSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -97,9 +109,7 @@ func resourceApplicationSetRead(ctx context.Context, d *schema.ResourceData, m i
 }
 func resourceApplicationSetReadWJnprSess(
 	d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
-	mutex.Lock()
 	applicationSetOptions, err := readApplicationSet(d.Get("name").(string), m, jnprSess)
-	mutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -114,29 +124,31 @@ func resourceApplicationSetReadWJnprSess(
 func resourceApplicationSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.Partial(true)
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delApplicationSet(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if err := setApplicationSet(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("update resource junos_application_set", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	d.Partial(false)
 
@@ -144,31 +156,34 @@ func resourceApplicationSetUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 func resourceApplicationSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delApplicationSet(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("delete resource junos_application_set", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 
 	return diagWarns
 }
-func resourceApplicationSetImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceApplicationSetImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +205,18 @@ func resourceApplicationSetImport(d *schema.ResourceData, m interface{}) ([]*sch
 
 	return result, nil
 }
+func resourceApplicationSetCommitCheck(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	return customizeDiffCommitCheck(ctx, "junos_application_set", resourceApplicationSet, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			if d.Id() != "" {
+				if err := delApplicationSet(d, m, jnprSess); err != nil {
+					return err
+				}
+			}
+
+			return setApplicationSet(d, m, jnprSess)
+		})
+}
 
 func checkApplicationSetExists(applicationSet string, m interface{}, jnprSess *NetconfObject) (bool, error) {
 	sess := m.(*Session)
@@ -16,8 +16,9 @@ func resourceBgpGroup() *schema.Resource {
 		ReadContext:   resourceBgpGroupRead,
 		UpdateContext: resourceBgpGroupUpdate,
 		DeleteContext: resourceBgpGroupDelete,
+		CustomizeDiff: resourceBgpGroupCommitCheck,
 		Importer: &schema.ResourceImporter{
-			State: resourceBgpGroupImport,
+			StateContext: resourceBgpGroupImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -475,49 +476,61 @@ func resourceBgpGroup() *schema.Resource {
 
 func resourceBgpGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if d.Get("routing_instance").(string) != defaultWord {
 		instanceExists, err := checkRoutingInstanceExists(d.Get("routing_instance").(string), m, jnprSess)
 		if err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 		if !instanceExists {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string)))
+			return append(diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string))),
+				diag.FromErr(clearErr)...)
 		}
 	}
 	bgpGroupxists, err := checkBgpGroupExists(d.Get("name").(string), d.Get("routing_instance").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
+	var diagWarns diag.Diagnostics
 	if bgpGroupxists {
-		sess.configClear(jnprSess)
+		existsErr := fmt.Errorf("bgp group %v already exists in routing-instance %v",
+			d.Get("name").(string), d.Get("routing_instance").(string))
+		if !sess.adoptExisting(d) {
+			clearErr := sess.configClear(jnprSess)
+
+			return append(diag.FromErr(existsErr), diag.FromErr(clearErr)...)
+		}
+		if err := delBgpGroup(d, m, jnprSess); err != nil {
+			clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("bgp group %v already exists in routing-instance %v",
-			d.Get("name").(string), d.Get("routing_instance").(string)))
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+		}
+		diagWarns = append(diagWarns, adoptedWarning("junos_bgp_group", existsErr))
 	}
 	if err := setBgpGroup(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
-	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("create resource junos_bgp_group", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	bgpGroupxists, err = checkBgpGroupExists(d.Get("name").(string), d.Get("routing_instance").(string), m, jnprSess)
 	if err != nil {
@@ -534,7 +547,7 @@ func resourceBgpGroupCreate(ctx context.Context, d *schema.ResourceData, m inter
 }
 func resourceBgpGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -543,9 +556,7 @@ func resourceBgpGroupRead(ctx context.Context, d *schema.ResourceData, m interfa
 	return resourceBgpGroupReadWJnprSess(d, m, jnprSess)
 }
 func resourceBgpGroupReadWJnprSess(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
-	mutex.Lock()
 	bgpGroupOptions, err := readBgpGroup(d.Get("name").(string), d.Get("routing_instance").(string), m, jnprSess)
-	mutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -560,29 +571,31 @@ func resourceBgpGroupReadWJnprSess(d *schema.ResourceData, m interface{}, jnprSe
 func resourceBgpGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.Partial(true)
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delBgpOpts(d, "group", m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if err := setBgpGroup(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("update resource junos_bgp_group", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	d.Partial(false)
 
@@ -590,31 +603,34 @@ func resourceBgpGroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 }
 func resourceBgpGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delBgpGroup(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("delete resource junos_bgp_group", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 
 	return diagWarns
 }
-func resourceBgpGroupImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceBgpGroupImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -641,6 +657,18 @@ func resourceBgpGroupImport(d *schema.ResourceData, m interface{}) ([]*schema.Re
 
 	return result, nil
 }
+func resourceBgpGroupCommitCheck(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	return customizeDiffCommitCheck(ctx, "junos_bgp_group", resourceBgpGroup, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			if d.Id() != "" {
+				if err := delBgpOpts(d, "group", m, jnprSess); err != nil {
+					return err
+				}
+			}
+
+			return setBgpGroup(d, m, jnprSess)
+		})
+}
 
 func checkBgpGroupExists(bgpGroup, instance string, m interface{}, jnprSess *NetconfObject) (bool, error) {
 	sess := m.(*Session)
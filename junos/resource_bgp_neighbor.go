@@ -16,8 +16,9 @@ func resourceBgpNeighbor() *schema.Resource {
 		ReadContext:   resourceBgpNeighborRead,
 		UpdateContext: resourceBgpNeighborUpdate,
 		DeleteContext: resourceBgpNeighborDelete,
+		CustomizeDiff: resourceBgpNeighborCommitCheck,
 		Importer: &schema.ResourceImporter{
-			State: resourceBgpNeighborImport,
+			StateContext: resourceBgpNeighborImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"ip": {
@@ -469,61 +470,74 @@ func resourceBgpNeighbor() *schema.Resource {
 
 func resourceBgpNeighborCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if d.Get("routing_instance").(string) != defaultWord {
 		instanceExists, err := checkRoutingInstanceExists(d.Get("routing_instance").(string), m, jnprSess)
 		if err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 		if !instanceExists {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string)))
+			return append(diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string))),
+				diag.FromErr(clearErr)...)
 		}
 	}
 	bgpGroupExists, err := checkBgpGroupExists(d.Get("group").(string), d.Get("routing_instance").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if !bgpGroupExists {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("bgp group %v doesn't exist", d.Get("group").(string)))
+		return append(diag.FromErr(fmt.Errorf("bgp group %v doesn't exist", d.Get("group").(string))),
+			diag.FromErr(clearErr)...)
 	}
 	bgpNeighborxists, err := checkBgpNeighborExists(d.Get("ip").(string),
 		d.Get("routing_instance").(string), d.Get("group").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
+	var diagWarns diag.Diagnostics
 	if bgpNeighborxists {
-		sess.configClear(jnprSess)
+		existsErr := fmt.Errorf("bgp neighbor %v already exists in group %v (routing-instance %v)",
+			d.Get("ip").(string), d.Get("group").(string), d.Get("routing_instance").(string))
+		if !sess.adoptExisting(d) {
+			clearErr := sess.configClear(jnprSess)
+
+			return append(diag.FromErr(existsErr), diag.FromErr(clearErr)...)
+		}
+		if err := delBgpNeighbor(d, m, jnprSess); err != nil {
+			clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("bgp neighbor %v already exists in group %v (routing-instance %v)",
-			d.Get("ip").(string), d.Get("group").(string), d.Get("routing_instance").(string)))
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+		}
+		diagWarns = append(diagWarns, adoptedWarning("junos_bgp_neighbor", existsErr))
 	}
 	if err := setBgpNeighbor(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
-	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("create resource junos_bgp_neighbor", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	bgpNeighborxists, err = checkBgpNeighborExists(d.Get("ip").(string),
 		d.Get("routing_instance").(string), d.Get("group").(string), m, jnprSess)
@@ -544,7 +558,7 @@ func resourceBgpNeighborCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 func resourceBgpNeighborRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -553,10 +567,8 @@ func resourceBgpNeighborRead(ctx context.Context, d *schema.ResourceData, m inte
 	return resourceBgpNeighborReadWJnprSess(d, m, jnprSess)
 }
 func resourceBgpNeighborReadWJnprSess(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
-	mutex.Lock()
 	bgpNeighborOptions, err := readBgpNeighbor(d.Get("ip").(string),
 		d.Get("routing_instance").(string), d.Get("group").(string), m, jnprSess)
-	mutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -571,29 +583,31 @@ func resourceBgpNeighborReadWJnprSess(d *schema.ResourceData, m interface{}, jnp
 func resourceBgpNeighborUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.Partial(true)
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delBgpOpts(d, "neighbor", m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if err := setBgpNeighbor(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("update resource junos_bgp_neighbor", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	d.Partial(false)
 
@@ -601,31 +615,34 @@ func resourceBgpNeighborUpdate(ctx context.Context, d *schema.ResourceData, m in
 }
 func resourceBgpNeighborDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delBgpNeighbor(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("delete resource junos_bgp_neighbor", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 
 	return diagWarns
 }
-func resourceBgpNeighborImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceBgpNeighborImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -652,6 +669,18 @@ func resourceBgpNeighborImport(d *schema.ResourceData, m interface{}) ([]*schema
 
 	return result, nil
 }
+func resourceBgpNeighborCommitCheck(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	return customizeDiffCommitCheck(ctx, "junos_bgp_neighbor", resourceBgpNeighbor, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			if d.Id() != "" {
+				if err := delBgpOpts(d, "neighbor", m, jnprSess); err != nil {
+					return err
+				}
+			}
+
+			return setBgpNeighbor(d, m, jnprSess)
+		})
+}
 
 func checkBgpNeighborExists(ip, instance, group string, m interface{}, jnprSess *NetconfObject) (bool, error) {
 	sess := m.(*Session)
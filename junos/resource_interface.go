@@ -46,8 +46,9 @@ func resourceInterface() *schema.Resource {
 		ReadContext:   resourceInterfaceRead,
 		UpdateContext: resourceInterfaceUpdate,
 		DeleteContext: resourceInterfaceDelete,
+		CustomizeDiff: resourceInterfaceCommitCheck,
 		Importer: &schema.ResourceImporter{
-			State: resourceInterfaceImport,
+			StateContext: resourceInterfaceImport,
 		},
 		DeprecationMessage: "use junos_interface_physical or junos_interface_logical resource instead",
 		Schema: map[string]*schema.Schema{
@@ -431,7 +432,7 @@ func resourceInterface() *schema.Resource {
 
 func resourceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -440,13 +441,15 @@ func resourceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if intExists {
 		ncInt, emptyInt, err := checkInterfaceNC(d.Get("name").(string), m, jnprSess)
 		if err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 		if !ncInt && !emptyInt {
 			return diag.FromErr(fmt.Errorf("interface %s already configured", d.Get("name").(string)))
@@ -454,69 +457,71 @@ func resourceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m inte
 		if sess.junosGroupIntDel != "" {
 			err = delInterfaceElement("apply-groups "+sess.junosGroupIntDel, d, m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 		} else {
 			err = delInterfaceElement("disable", d, m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 			err = delInterfaceElement("description", d, m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 		}
 	}
 	if d.Get("security_zone").(string) != "" {
 		if !checkCompatibilitySecurity(jnprSess) {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(fmt.Errorf("security zone not compatible with Junos device %s",
-				jnprSess.SystemInformation.HardwareModel))
+			return append(diag.FromErr(fmt.Errorf("security zone not compatible with Junos device %s",
+				jnprSess.SystemInformation.HardwareModel)), diag.FromErr(clearErr)...)
 		}
 		zonesExists, err := checkSecurityZonesExists(d.Get("security_zone").(string), m, jnprSess)
 		if err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 		if !zonesExists {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(fmt.Errorf("security zones %v doesn't exist", d.Get("security_zone").(string)))
+			return append(diag.FromErr(fmt.Errorf("security zones %v doesn't exist", d.Get("security_zone").(string))),
+				diag.FromErr(clearErr)...)
 		}
 	}
 	if d.Get("routing_instance").(string) != "" {
 		instanceExists, err := checkRoutingInstanceExists(d.Get("routing_instance").(string), m, jnprSess)
 		if err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 		if !instanceExists {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string)))
+			return append(diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string))),
+				diag.FromErr(clearErr)...)
 		}
 	}
 	if err := setInterface(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("create resource junos_interface", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	intExists, err = checkInterfaceExistsOld(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -542,7 +547,7 @@ func resourceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m inte
 }
 func resourceInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -551,33 +556,25 @@ func resourceInterfaceRead(ctx context.Context, d *schema.ResourceData, m interf
 	return resourceInterfaceReadWJnprSess(d, m, jnprSess)
 }
 func resourceInterfaceReadWJnprSess(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
-	mutex.Lock()
 	intExists, err := checkInterfaceExistsOld(d.Get("name").(string), m, jnprSess)
 	if err != nil {
-		mutex.Unlock()
-
 		return diag.FromErr(err)
 	}
 	if !intExists {
 		d.SetId("")
-		mutex.Unlock()
 
 		return nil
 	}
 	ncInt, _, err := checkInterfaceNC(d.Get("name").(string), m, jnprSess)
 	if err != nil {
-		mutex.Unlock()
-
 		return diag.FromErr(err)
 	}
 	if ncInt {
 		d.SetId("")
-		mutex.Unlock()
 
 		return nil
 	}
 	interfaceOpt, err := readInterface(d.Get("name").(string), m, jnprSess)
-	mutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -588,16 +585,18 @@ func resourceInterfaceReadWJnprSess(d *schema.ResourceData, m interface{}, jnprS
 func resourceInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.Partial(true)
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delInterfaceOpts(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if d.HasChange("ether802_3ad") {
 		oAE, nAE := d.GetChange("ether802_3ad")
@@ -608,64 +607,64 @@ func resourceInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			}
 			lastAEchild, err := aggregatedLastChild(oAE.(string), d.Get("name").(string), m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 			if lastAEchild {
 				aggregatedCount, err := aggregatedCountSearchMax(newAE, oAE.(string), d.Get("name").(string), m, jnprSess)
 				if err != nil {
-					sess.configClear(jnprSess)
+					clearErr := sess.configClear(jnprSess)
 
-					return diag.FromErr(err)
+					return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 				}
 				if aggregatedCount == "0" {
 					err = sess.configSet([]string{"delete chassis aggregated-devices ethernet device-count"}, jnprSess)
 					if err != nil {
-						sess.configClear(jnprSess)
+						clearErr := sess.configClear(jnprSess)
 
-						return diag.FromErr(err)
+						return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 					}
 					oAEintNC, oAEintEmpty, err := checkInterfaceNC(oAE.(string), m, jnprSess)
 					if err != nil {
-						sess.configClear(jnprSess)
+						clearErr := sess.configClear(jnprSess)
 
-						return diag.FromErr(err)
+						return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 					}
 					if oAEintNC || oAEintEmpty {
 						err = sess.configSet([]string{"delete interfaces " + oAE.(string)}, jnprSess)
 						if err != nil {
-							sess.configClear(jnprSess)
+							clearErr := sess.configClear(jnprSess)
 
-							return diag.FromErr(err)
+							return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 						}
 					}
 				} else {
 					oldAEInt, err := strconv.Atoi(strings.TrimPrefix(oAE.(string), "ae"))
 					if err != nil {
-						sess.configClear(jnprSess)
+						clearErr := sess.configClear(jnprSess)
 
-						return diag.FromErr(err)
+						return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 					}
 					aggregatedCountInt, err := strconv.Atoi(aggregatedCount)
 					if err != nil {
-						sess.configClear(jnprSess)
+						clearErr := sess.configClear(jnprSess)
 
-						return diag.FromErr(err)
+						return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 					}
 					if aggregatedCountInt < oldAEInt+1 {
 						oAEintNC, oAEintEmpty, err := checkInterfaceNC(oAE.(string), m, jnprSess)
 						if err != nil {
-							sess.configClear(jnprSess)
+							clearErr := sess.configClear(jnprSess)
 
-							return diag.FromErr(err)
+							return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 						}
 						if oAEintNC || oAEintEmpty {
 							err = sess.configSet([]string{"delete interfaces " + oAE.(string)}, jnprSess)
 							if err != nil {
-								sess.configClear(jnprSess)
+								clearErr := sess.configClear(jnprSess)
 
-								return diag.FromErr(err)
+								return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 							}
 						}
 					}
@@ -677,29 +676,30 @@ func resourceInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		oSecurityZone, nSecurityZone := d.GetChange("security_zone")
 		if nSecurityZone.(string) != "" {
 			if !checkCompatibilitySecurity(jnprSess) {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(fmt.Errorf("security zone not compatible with Junos device %s",
-					jnprSess.SystemInformation.HardwareModel))
+				return append(diag.FromErr(fmt.Errorf("security zone not compatible with Junos device %s",
+					jnprSess.SystemInformation.HardwareModel)), diag.FromErr(clearErr)...)
 			}
 			zonesExists, err := checkSecurityZonesExists(nSecurityZone.(string), m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 			if !zonesExists {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(fmt.Errorf("security zones %v doesn't exist", nSecurityZone.(string)))
+				return append(diag.FromErr(fmt.Errorf("security zones %v doesn't exist", nSecurityZone.(string))),
+					diag.FromErr(clearErr)...)
 			}
 		}
 		if oSecurityZone.(string) != "" {
 			err = delZoneInterface(oSecurityZone.(string), d, m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 		}
 	}
@@ -708,37 +708,38 @@ func resourceInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		if nRoutingInstance.(string) != "" {
 			instanceExists, err := checkRoutingInstanceExists(nRoutingInstance.(string), m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 			if !instanceExists {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", nRoutingInstance.(string)))
+				return append(diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", nRoutingInstance.(string))),
+					diag.FromErr(clearErr)...)
 			}
 		}
 		if oRoutingInstance.(string) != "" {
 			err = delRoutingInstanceInterface(oRoutingInstance.(string), d, m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 		}
 	}
 	if err := setInterface(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("update resource junos_interface", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	d.Partial(false)
 
@@ -746,24 +747,26 @@ func resourceInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m inte
 }
 func resourceInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delInterface(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("delete resource junos_interface", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	if !d.Get("complete_destroy").(bool) {
 		intExists, err := checkInterfaceExistsOld(d.Get("name").(string), m, jnprSess)
@@ -773,24 +776,25 @@ func resourceInterfaceDelete(ctx context.Context, d *schema.ResourceData, m inte
 		if intExists {
 			err = addInterfaceNC(d.Get("name").(string), m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return append(diagWarns, diag.FromErr(err)...)
+				return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 			}
 			_, err = sess.commitConf("disable(NC) resource junos_interface", jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return append(diagWarns, diag.FromErr(err)...)
+				return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 			}
 		}
 	}
 
 	return diagWarns
 }
-func resourceInterfaceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceInterfaceImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -816,6 +820,30 @@ func resourceInterfaceImport(d *schema.ResourceData, m interface{}) ([]*schema.R
 
 	return result, nil
 }
+func resourceInterfaceCommitCheck(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	return customizeDiffCommitCheck(ctx, "junos_interface", resourceInterface, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			if d.Id() != "" {
+				if err := delInterfaceOpts(d, m, jnprSess); err != nil {
+					return err
+				}
+				if oSecurityZone, _ := d.GetChange("security_zone"); oSecurityZone.(string) != "" &&
+					d.HasChange("security_zone") {
+					if err := delZoneInterface(oSecurityZone.(string), d, m, jnprSess); err != nil {
+						return err
+					}
+				}
+				if oRoutingInstance, _ := d.GetChange("routing_instance"); oRoutingInstance.(string) != "" &&
+					d.HasChange("routing_instance") {
+					if err := delRoutingInstanceInterface(oRoutingInstance.(string), d, m, jnprSess); err != nil {
+						return err
+					}
+				}
+			}
+
+			return setInterface(d, m, jnprSess)
+		})
+}
 
 func checkInterfaceNC(interFace string, m interface{}, jnprSess *NetconfObject) (
 	ncInt bool, emtyInt bool, errFunc error) {
@@ -28,8 +28,9 @@ func resourceInterfaceLogical() *schema.Resource {
 		ReadContext:   resourceInterfaceLogicalRead,
 		UpdateContext: resourceInterfaceLogicalUpdate,
 		DeleteContext: resourceInterfaceLogicalDelete,
+		CustomizeDiff: resourceInterfaceLogicalCommitCheck,
 		Importer: &schema.ResourceImporter{
-			State: resourceInterfaceLogicalImport,
+			StateContext: resourceInterfaceLogicalImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -374,74 +375,79 @@ func resourceInterfaceLogical() *schema.Resource {
 
 func resourceInterfaceLogicalCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	ncInt, emptyInt, _, err := checkInterfaceLogicalNCEmpty(d.Get("name").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if !ncInt && !emptyInt {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("interface %s already configured", d.Get("name").(string)))
+		return append(diag.FromErr(fmt.Errorf("interface %s already configured", d.Get("name").(string))),
+			diag.FromErr(clearErr)...)
 	}
 	if ncInt {
 		if err := delInterfaceNC(d, m, jnprSess); err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 	}
 	if d.Get("security_zone").(string) != "" {
 		if !checkCompatibilitySecurity(jnprSess) {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(fmt.Errorf("security zone not compatible with Junos device %s",
-				jnprSess.SystemInformation.HardwareModel))
+			return append(diag.FromErr(fmt.Errorf("security zone not compatible with Junos device %s",
+				jnprSess.SystemInformation.HardwareModel)), diag.FromErr(clearErr)...)
 		}
 		zonesExists, err := checkSecurityZonesExists(d.Get("security_zone").(string), m, jnprSess)
 		if err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 		if !zonesExists {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(fmt.Errorf("security zones %v doesn't exist", d.Get("security_zone").(string)))
+			return append(diag.FromErr(fmt.Errorf("security zones %v doesn't exist", d.Get("security_zone").(string))),
+				diag.FromErr(clearErr)...)
 		}
 	}
 	if d.Get("routing_instance").(string) != "" {
 		instanceExists, err := checkRoutingInstanceExists(d.Get("routing_instance").(string), m, jnprSess)
 		if err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 		if !instanceExists {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string)))
+			return append(diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string))),
+				diag.FromErr(clearErr)...)
 		}
 	}
 	if err := setInterfaceLogical(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("create resource junos_interface_logical", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	ncInt, emptyInt, setInt, err := checkInterfaceLogicalNCEmpty(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -467,7 +473,7 @@ func resourceInterfaceLogicalCreate(ctx context.Context, d *schema.ResourceData,
 }
 func resourceInterfaceLogicalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -477,35 +483,27 @@ func resourceInterfaceLogicalRead(ctx context.Context, d *schema.ResourceData, m
 }
 func resourceInterfaceLogicalReadWJnprSess(
 	d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
-	mutex.Lock()
 	ncInt, emptyInt, setInt, err := checkInterfaceLogicalNCEmpty(d.Get("name").(string), m, jnprSess)
 	if err != nil {
-		mutex.Unlock()
-
 		return diag.FromErr(err)
 	}
 	if ncInt {
 		d.SetId("")
-		mutex.Unlock()
 
 		return nil
 	}
 	if emptyInt && !setInt {
 		intExists, err := checkInterfaceExists(d.Get("name").(string), m, jnprSess)
 		if err != nil {
-			mutex.Unlock()
-
 			return diag.FromErr(err)
 		}
 		if !intExists {
 			d.SetId("")
-			mutex.Unlock()
 
 			return nil
 		}
 	}
 	interfaceLogicalOpt, err := readInterfaceLogical(d.Get("name").(string), m, jnprSess)
-	mutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -516,44 +514,47 @@ func resourceInterfaceLogicalReadWJnprSess(
 func resourceInterfaceLogicalUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.Partial(true)
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delInterfaceLogicalOpts(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if d.HasChange("security_zone") {
 		oSecurityZone, nSecurityZone := d.GetChange("security_zone")
 		if nSecurityZone.(string) != "" {
 			if !checkCompatibilitySecurity(jnprSess) {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(fmt.Errorf("security zone not compatible with Junos device %s",
-					jnprSess.SystemInformation.HardwareModel))
+				return append(diag.FromErr(fmt.Errorf("security zone not compatible with Junos device %s",
+					jnprSess.SystemInformation.HardwareModel)), diag.FromErr(clearErr)...)
 			}
 			zonesExists, err := checkSecurityZonesExists(nSecurityZone.(string), m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 			if !zonesExists {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(fmt.Errorf("security zones %v doesn't exist", nSecurityZone.(string)))
+				return append(diag.FromErr(fmt.Errorf("security zones %v doesn't exist", nSecurityZone.(string))),
+					diag.FromErr(clearErr)...)
 			}
 		}
 		if oSecurityZone.(string) != "" {
 			err = delZoneInterfaceLogical(oSecurityZone.(string), d, m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 		}
 	}
@@ -562,37 +563,38 @@ func resourceInterfaceLogicalUpdate(ctx context.Context, d *schema.ResourceData,
 		if nRoutingInstance.(string) != "" {
 			instanceExists, err := checkRoutingInstanceExists(nRoutingInstance.(string), m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 			if !instanceExists {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", nRoutingInstance.(string)))
+				return append(diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", nRoutingInstance.(string))),
+					diag.FromErr(clearErr)...)
 			}
 		}
 		if oRoutingInstance.(string) != "" {
 			err = delRoutingInstanceInterfaceLogical(oRoutingInstance.(string), d, m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return diag.FromErr(err)
+				return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 			}
 		}
 	}
 	if err := setInterfaceLogical(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("update resource junos_interface_logical", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	d.Partial(false)
 
@@ -600,34 +602,37 @@ func resourceInterfaceLogicalUpdate(ctx context.Context, d *schema.ResourceData,
 }
 func resourceInterfaceLogicalDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delInterfaceLogical(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("delete resource junos_interface_logical", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 
 	return diagWarns
 }
-func resourceInterfaceLogicalImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceInterfaceLogicalImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	if strings.Count(d.Id(), ".") != 1 {
 		return nil, fmt.Errorf("name of interface %s need to have 1 dot", d.Id())
 	}
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -662,6 +667,30 @@ func resourceInterfaceLogicalImport(d *schema.ResourceData, m interface{}) ([]*s
 
 	return result, nil
 }
+func resourceInterfaceLogicalCommitCheck(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	return customizeDiffCommitCheck(ctx, "junos_interface_logical", resourceInterfaceLogical, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			if d.Id() != "" {
+				if err := delInterfaceLogicalOpts(d, m, jnprSess); err != nil {
+					return err
+				}
+				if oSecurityZone, _ := d.GetChange("security_zone"); oSecurityZone.(string) != "" &&
+					d.HasChange("security_zone") {
+					if err := delZoneInterfaceLogical(oSecurityZone.(string), d, m, jnprSess); err != nil {
+						return err
+					}
+				}
+				if oRoutingInstance, _ := d.GetChange("routing_instance"); oRoutingInstance.(string) != "" &&
+					d.HasChange("routing_instance") {
+					if err := delRoutingInstanceInterfaceLogical(oRoutingInstance.(string), d, m, jnprSess); err != nil {
+						return err
+					}
+				}
+			}
+
+			return setInterfaceLogical(d, m, jnprSess)
+		})
+}
 
 func checkInterfaceLogicalNCEmpty(interFace string, m interface{}, jnprSess *NetconfObject) (
 	ncInt bool, emtyInt bool, justSet bool, _err error) {
@@ -31,8 +31,9 @@ func resourceInterfacePhysical() *schema.Resource {
 		ReadContext:   resourceInterfacePhysicalRead,
 		UpdateContext: resourceInterfacePhysicalUpdate,
 		DeleteContext: resourceInterfacePhysicalDelete,
+		CustomizeDiff: resourceInterfacePhysicalCommitCheck,
 		Importer: &schema.ResourceImporter{
-			State: resourceInterfacePhysicalImport,
+			StateContext: resourceInterfacePhysicalImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -109,42 +110,45 @@ func resourceInterfacePhysical() *schema.Resource {
 
 func resourceInterfacePhysicalCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	ncInt, emptyInt, err := checkInterfacePhysicalNCEmpty(d.Get("name").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if !ncInt && !emptyInt {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("interface %s already configured", d.Get("name").(string)))
+		return append(diag.FromErr(fmt.Errorf("interface %s already configured", d.Get("name").(string))),
+			diag.FromErr(clearErr)...)
 	}
 	if ncInt {
 		if err := delInterfaceNC(d, m, jnprSess); err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 	}
 	if err := setInterfacePhysical(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("create resource junos_interface_physical", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	ncInt, emptyInt, err = checkInterfacePhysicalNCEmpty(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -170,7 +174,7 @@ func resourceInterfacePhysicalCreate(ctx context.Context, d *schema.ResourceData
 }
 func resourceInterfacePhysicalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -180,35 +184,27 @@ func resourceInterfacePhysicalRead(ctx context.Context, d *schema.ResourceData,
 }
 func resourceInterfacePhysicalReadWJnprSess(
 	d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
-	mutex.Lock()
 	ncInt, emptyInt, err := checkInterfacePhysicalNCEmpty(d.Get("name").(string), m, jnprSess)
 	if err != nil {
-		mutex.Unlock()
-
 		return diag.FromErr(err)
 	}
 	if ncInt {
 		d.SetId("")
-		mutex.Unlock()
 
 		return nil
 	}
 	if emptyInt {
 		intExists, err := checkInterfaceExists(d.Get("name").(string), m, jnprSess)
 		if err != nil {
-			mutex.Unlock()
-
 			return diag.FromErr(err)
 		}
 		if !intExists {
 			d.SetId("")
-			mutex.Unlock()
 
 			return nil
 		}
 	}
 	interfaceOpt, err := readInterfacePhysical(d.Get("name").(string), m, jnprSess)
-	mutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -219,29 +215,31 @@ func resourceInterfacePhysicalReadWJnprSess(
 func resourceInterfacePhysicalUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.Partial(true)
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delInterfacePhysicalOpts(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if err := setInterfacePhysical(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("update resource junos_interface_physical", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	d.Partial(false)
 
@@ -249,24 +247,26 @@ func resourceInterfacePhysicalUpdate(ctx context.Context, d *schema.ResourceData
 }
 func resourceInterfacePhysicalDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delInterfacePhysical(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("delete resource junos_interface_physical", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	if !d.Get("no_disable_on_destroy").(bool) {
 		intExists, err := checkInterfaceExists(d.Get("name").(string), m, jnprSess)
@@ -275,27 +275,28 @@ func resourceInterfacePhysicalDelete(ctx context.Context, d *schema.ResourceData
 		} else if intExists {
 			err = addInterfacePhysicalNC(d.Get("name").(string), m, jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return append(diagWarns, diag.FromErr(err)...)
+				return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 			}
 			_, err = sess.commitConf("disable(NC) resource junos_interface_physical", jnprSess)
 			if err != nil {
-				sess.configClear(jnprSess)
+				clearErr := sess.configClear(jnprSess)
 
-				return append(diagWarns, diag.FromErr(err)...)
+				return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 			}
 		}
 	}
 
 	return diagWarns
 }
-func resourceInterfacePhysicalImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceInterfacePhysicalImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	if strings.Count(d.Id(), ".") != 0 {
 		return nil, fmt.Errorf("name of interface %s need to doesn't have a dot", d.Id())
 	}
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -330,6 +331,18 @@ func resourceInterfacePhysicalImport(d *schema.ResourceData, m interface{}) ([]*
 
 	return result, nil
 }
+func resourceInterfacePhysicalCommitCheck(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	return customizeDiffCommitCheck(ctx, "junos_interface_physical", resourceInterfacePhysical, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			if d.Id() != "" {
+				if err := delInterfacePhysicalOpts(d, m, jnprSess); err != nil {
+					return err
+				}
+			}
+
+			return setInterfacePhysical(d, m, jnprSess)
+		})
+}
 
 func checkInterfacePhysicalNCEmpty(interFace string, m interface{}, jnprSess *NetconfObject) (
 	ncInt bool, emtyInt bool, errFunc error) {
@@ -0,0 +1,627 @@
+package junos
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type rawConfigOptions struct {
+	path  string
+	lines []string
+}
+
+func resourceRawConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRawConfigCreate,
+		ReadContext:   resourceRawConfigRead,
+		UpdateContext: resourceRawConfigUpdate,
+		DeleteContext: resourceRawConfigDelete,
+		CustomizeDiff: resourceRawConfigCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRawConfigImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringIsNotWhiteSpace,
+					validation.StringDoesNotMatch(regexp.MustCompile(`^(set|delete|show) `),
+						"path need to be a configuration path without command"),
+					validation.StringDoesNotContainAny("|\n"),
+				),
+			},
+			"lines": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				MinItems:     1,
+				ExactlyOneOf: []string{"lines", "text", "xml"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.All(
+						validation.StringIsNotWhiteSpace,
+						validation.StringDoesNotMatch(regexp.MustCompile(`^(set|delete) `),
+							"line need to be relative to path without set or delete command"),
+					),
+					StateFunc: func(v interface{}) string {
+						return rawConfigLineNormalize(v.(string))
+					},
+				},
+				Set: func(v interface{}) int {
+					return schema.HashString(rawConfigLineNormalize(v.(string)))
+				},
+			},
+			"text": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"lines", "text", "xml"},
+				ValidateFunc: func(v interface{}, k string) (warns []string, errs []error) {
+					if _, err := rawConfigTextLines(v.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%s: %w", k, err))
+					}
+
+					return
+				},
+			},
+			"xml": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"lines", "text", "xml"},
+				ValidateFunc: func(v interface{}, k string) (warns []string, errs []error) {
+					if _, err := rawConfigXMLLines(v.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%s: %w", k, err))
+					}
+
+					return
+				},
+			},
+		},
+	}
+}
+
+func resourceRawConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	rawConfigExists, err := checkRawConfigExists(d.Get("path").(string), m, jnprSess)
+	if err != nil {
+		clearErr := sess.configClear(jnprSess)
+
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+	}
+	var diagWarns diag.Diagnostics
+	if rawConfigExists {
+		existsErr := fmt.Errorf("configuration under %v already exists", d.Get("path").(string))
+		if !sess.adoptExisting(d) {
+			clearErr := sess.configClear(jnprSess)
+
+			return append(diag.FromErr(existsErr), diag.FromErr(clearErr)...)
+		}
+		if err := delRawConfig(d.Get("path").(string), m, jnprSess); err != nil {
+			clearErr := sess.configClear(jnprSess)
+
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+		}
+		diagWarns = append(diagWarns, adoptedWarning("junos_raw_config", existsErr))
+	}
+	if err := setRawConfig(d, m, jnprSess); err != nil {
+		clearErr := sess.configClear(jnprSess)
+
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+	}
+	warns, err := sess.commitConf("create resource junos_raw_config", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		clearErr := sess.configClear(jnprSess)
+
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
+	}
+	rawConfigExists, err = checkRawConfigExists(d.Get("path").(string), m, jnprSess)
+	if err != nil {
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	if rawConfigExists {
+		d.SetId(d.Get("path").(string))
+	} else {
+		return append(diagWarns, diag.FromErr(fmt.Errorf("configuration under %v not exists after commit "+
+			"=> check your config", d.Get("path").(string)))...)
+	}
+
+	return append(diagWarns, resourceRawConfigReadWJnprSess(d, m, jnprSess)...)
+}
+func resourceRawConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+
+	return resourceRawConfigReadWJnprSess(d, m, jnprSess)
+}
+func resourceRawConfigReadWJnprSess(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
+	rawConfigOptions, err := readRawConfig(d.Get("path").(string), m, jnprSess)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if rawConfigOptions.path == "" {
+		d.SetId("")
+	} else {
+		fillRawConfigData(d, rawConfigOptions)
+	}
+
+	return nil
+}
+func resourceRawConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := delRawConfig(d.Get("path").(string), m, jnprSess); err != nil {
+		clearErr := sess.configClear(jnprSess)
+
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+	}
+	if err := setRawConfig(d, m, jnprSess); err != nil {
+		clearErr := sess.configClear(jnprSess)
+
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+	}
+	var diagWarns diag.Diagnostics
+	warns, err := sess.commitConf("update resource junos_raw_config", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		clearErr := sess.configClear(jnprSess)
+
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
+	}
+	d.Partial(false)
+
+	return append(diagWarns, resourceRawConfigReadWJnprSess(d, m, jnprSess)...)
+}
+func resourceRawConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := delRawConfig(d.Get("path").(string), m, jnprSess); err != nil {
+		clearErr := sess.configClear(jnprSess)
+
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+	}
+	var diagWarns diag.Diagnostics
+	warns, err := sess.commitConf("delete resource junos_raw_config", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		clearErr := sess.configClear(jnprSess)
+
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
+	}
+
+	return diagWarns
+}
+func resourceRawConfigImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.closeSession(jnprSess)
+	result := make([]*schema.ResourceData, 1)
+
+	rawConfigExists, err := checkRawConfigExists(d.Id(), m, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	if !rawConfigExists {
+		return nil, fmt.Errorf("don't find configuration with id '%v' (id must be <path>)", d.Id())
+	}
+	rawConfigOptions, err := readRawConfig(d.Id(), m, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	fillRawConfigData(d, rawConfigOptions)
+
+	result[0] = d
+
+	return result, nil
+}
+func resourceRawConfigCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	// with text or xml, lines are the lines of snippet to detect the changes outside Terraform
+	for _, k := range []string{"text", "xml"} {
+		if !diff.NewValueKnown(k) {
+			if err := diff.SetNewComputed("lines"); err != nil {
+				return err
+			}
+
+			break
+		}
+		if diff.Get(k).(string) == "" {
+			continue
+		}
+		lines, err := rawConfigSnippetLines(k, diff.Get(k).(string))
+		if err != nil {
+			return err
+		}
+		if err := diff.SetNew("lines", lines); err != nil {
+			return err
+		}
+
+		break
+	}
+
+	return customizeDiffCommitCheck(ctx, "junos_raw_config", resourceRawConfig, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			if d.Id() != "" {
+				if err := delRawConfig(d.Get("path").(string), m, jnprSess); err != nil {
+					return err
+				}
+			}
+
+			return setRawConfig(d, m, jnprSess)
+		})
+}
+
+func checkRawConfigExists(path string, m interface{}, jnprSess *NetconfObject) (bool, error) {
+	sess := m.(*Session)
+	rawConfig, err := sess.command("show configuration "+path+" | display set", jnprSess)
+	if err != nil {
+		return false, err
+	}
+	if rawConfig == emptyWord {
+		return false, nil
+	}
+
+	return true, nil
+}
+func setRawConfig(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	configSet := make([]string, 0)
+
+	setPrefix := "set " + d.Get("path").(string) + " "
+	lines, err := rawConfigLines(d)
+	if err != nil {
+		return err
+	}
+	for _, v := range lines {
+		configSet = append(configSet, setPrefix+v)
+	}
+	if err := sess.configSet(configSet, jnprSess); err != nil {
+		return err
+	}
+
+	return nil
+}
+func readRawConfig(path string, m interface{}, jnprSess *NetconfObject) (rawConfigOptions, error) {
+	sess := m.(*Session)
+	var confRead rawConfigOptions
+
+	rawConfig, err := sess.command("show configuration "+path+" | display set relative", jnprSess)
+	if err != nil {
+		return confRead, err
+	}
+	if rawConfig != emptyWord {
+		confRead.path = path
+		for _, item := range strings.Split(rawConfig, "\n") {
+			if strings.Contains(item, "<configuration-output>") {
+				continue
+			}
+			if strings.Contains(item, "</configuration-output>") {
+				break
+			}
+			itemTrim := strings.TrimSpace(strings.TrimPrefix(item, setLineStart))
+			if itemTrim == "" || itemTrim == setWord {
+				continue
+			}
+			confRead.lines = append(confRead.lines, rawConfigLineNormalize(itemTrim))
+		}
+	}
+
+	return confRead, nil
+}
+
+func delRawConfig(path string, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	configSet := make([]string, 0, 1)
+	configSet = append(configSet, "delete "+path)
+	if err := sess.configSet(configSet, jnprSess); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func fillRawConfigData(d *schema.ResourceData, rawConfigOptions rawConfigOptions) {
+	if tfErr := d.Set("path", rawConfigOptions.path); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("lines", rawConfigOptions.lines); tfErr != nil {
+		panic(tfErr)
+	}
+}
+
+// rawConfigLines returns the lines to set under path, from text or xml in order of snippet or from lines.
+func rawConfigLines(d *schema.ResourceData) ([]string, error) {
+	for _, k := range []string{"text", "xml"} {
+		if v := d.Get(k).(string); v != "" {
+			return rawConfigSnippetLines(k, v)
+		}
+	}
+	lines := make([]string, 0)
+	for _, v := range d.Get("lines").(*schema.Set).List() {
+		lines = append(lines, rawConfigLineNormalize(v.(string)))
+	}
+
+	return lines, nil
+}
+
+func rawConfigSnippetLines(format, snippet string) ([]string, error) {
+	if format == "xml" {
+		return rawConfigXMLLines(snippet)
+	}
+
+	return rawConfigTextLines(snippet)
+}
+
+// rawConfigLineNormalize returns the line with words separated by a single space
+// (spaces in quoted strings are kept) like in the output of 'display set'.
+func rawConfigLineNormalize(line string) string {
+	var normalized strings.Builder
+	quoted, escaped, space := false, false, false
+	for _, r := range strings.TrimSpace(line) {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && unicode.IsSpace(r):
+			space = true
+
+			continue
+		}
+		if space {
+			normalized.WriteByte(' ')
+			space = false
+		}
+		normalized.WriteRune(r)
+	}
+
+	return normalized.String()
+}
+
+// rawConfigQuote returns the value with quotes if needed in a set line.
+func rawConfigQuote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n;{}[]#\"\\") {
+		return value
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// rawConfigTextTokens splits a configuration in text format in words (quoted strings keep their quotes)
+// and punctuation (; { } [ ]), comments are removed.
+func rawConfigTextTokens(text string) ([]string, error) {
+	tokens := make([]string, 0)
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.ContainsRune(";{}[]", rune(c)):
+			tokens = append(tokens, string(c))
+			i++
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end == -1 {
+				return nil, errors.New("comment '/*' without '*/'")
+			}
+			i += end + 4
+		case c == '#':
+			end := strings.IndexByte(text[i:], '\n')
+			if end == -1 {
+				end = len(text) - i
+			}
+			i += end
+		case c == '"':
+			end := i + 1
+			for ; end < len(text) && text[end] != '"'; end++ {
+				if text[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(text) {
+				return nil, errors.New("quoted string without closing quote")
+			}
+			tokens = append(tokens, text[i:end+1])
+			i = end + 1
+		default:
+			end := i
+			for end < len(text) && !strings.ContainsRune(" \t\n\r;{}[]\"", rune(text[end])) {
+				end++
+			}
+			tokens = append(tokens, text[i:end])
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+// rawConfigTextLines returns the relative set lines (without set command) of a configuration in text format
+// (with curly braces, like the output of 'show configuration').
+func rawConfigTextLines(text string) ([]string, error) {
+	tokens, err := rawConfigTextTokens(text)
+	if err != nil {
+		return nil, err
+	}
+	type container struct {
+		children int
+		words    []string
+	}
+	lines := make([]string, 0)
+	stack := make([]*container, 0)
+	addLine := func(words []string) {
+		line := make([]string, 0)
+		for _, c := range stack {
+			line = append(line, c.words...)
+		}
+		lines = append(lines, strings.Join(append(line, words...), " "))
+		if len(stack) > 0 {
+			stack[len(stack)-1].children++
+		}
+	}
+	words := make([]string, 0)
+	for i := 0; i < len(tokens); i++ {
+		switch tok := tokens[i]; tok {
+		case "{":
+			if len(words) == 0 {
+				return nil, errors.New("missing statement before '{'")
+			}
+			stack = append(stack, &container{words: words})
+			words = make([]string, 0)
+		case "}":
+			if len(words) > 0 {
+				return nil, fmt.Errorf("missing ';' after '%s'", strings.Join(words, " "))
+			}
+			if len(stack) == 0 {
+				return nil, errors.New("unexpected '}'")
+			}
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			switch {
+			case last.children == 0:
+				addLine(last.words)
+			case len(stack) > 0:
+				stack[len(stack)-1].children++
+			}
+		case ";":
+			if len(words) > 0 {
+				addLine(words)
+				words = make([]string, 0)
+			}
+		case "[":
+			if len(words) == 0 {
+				return nil, errors.New("missing statement before '['")
+			}
+			i++
+			for ; i < len(tokens) && tokens[i] != "]"; i++ {
+				if strings.ContainsAny(tokens[i], ";{}[") && !strings.HasPrefix(tokens[i], `"`) {
+					return nil, fmt.Errorf("unexpected '%s' in list of '%s'", tokens[i], strings.Join(words, " "))
+				}
+				addLine(append(words[:len(words):len(words)], tokens[i]))
+			}
+			if i == len(tokens) {
+				return nil, fmt.Errorf("missing ']' after '%s'", strings.Join(words, " "))
+			}
+			words = make([]string, 0)
+		case "]":
+			return nil, errors.New("unexpected ']'")
+		default:
+			if len(words) == 0 && (tok == "inactive:" || tok == "protect:") {
+				return nil, fmt.Errorf("'%s' statements not supported", tok)
+			}
+			words = append(words, tok)
+		}
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing '}' after '%s'", strings.Join(stack[len(stack)-1].words, " "))
+	}
+	if len(words) > 0 {
+		return nil, fmt.Errorf("missing ';' after '%s'", strings.Join(words, " "))
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("no configuration statement")
+	}
+
+	return lines, nil
+}
+
+type rawConfigXMLElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr            `xml:",any,attr"`
+	Text     string                `xml:",chardata"`
+	Children []rawConfigXMLElement `xml:",any"`
+}
+
+// rawConfigXMLLines returns the relative set lines (without set command) of a configuration in XML format
+// (elements under path, like the output of 'display xml'), the <name> element is the key of its parent.
+func rawConfigXMLLines(snippet string) ([]string, error) {
+	var configuration rawConfigXMLElement
+	if err := xml.Unmarshal([]byte("<configuration>"+snippet+"</configuration>"), &configuration); err != nil {
+		return nil, fmt.Errorf("failed to decode xml : %w", err)
+	}
+	lines := make([]string, 0)
+	for _, element := range configuration.Children {
+		elementLines, err := rawConfigXMLElementLines(nil, element)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, elementLines...)
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("no configuration element")
+	}
+
+	return lines, nil
+}
+
+func rawConfigXMLElementLines(prefix []string, element rawConfigXMLElement) ([]string, error) {
+	for _, attr := range element.Attrs {
+		if attr.Name.Local == "inactive" || attr.Name.Local == "protect" {
+			return nil, fmt.Errorf("'%s' attribute on element '%s' not supported", attr.Name.Local, element.XMLName.Local)
+		}
+	}
+	words := append(prefix[:len(prefix):len(prefix)], element.XMLName.Local)
+	children := element.Children
+	if len(children) > 0 && children[0].XMLName.Local == "name" && len(children[0].Children) == 0 {
+		words = append(words, rawConfigQuote(strings.TrimSpace(children[0].Text)))
+		children = children[1:]
+	}
+	if len(children) == 0 {
+		if value := strings.TrimSpace(element.Text); value != "" && len(element.Children) == 0 {
+			words = append(words, rawConfigQuote(value))
+		}
+
+		return []string{strings.Join(words, " ")}, nil
+	}
+	lines := make([]string, 0)
+	for _, child := range children {
+		childLines, err := rawConfigXMLElementLines(words, child)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, childLines...)
+	}
+
+	return lines, nil
+}
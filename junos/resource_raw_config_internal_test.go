@@ -0,0 +1,194 @@
+package junos
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestRawConfigLineNormalize(t *testing.T) {
+	tests := map[string]string{
+		"authorization read-only":              "authorization read-only",
+		"  clients   192.0.2.0/24 ":            "clients 192.0.2.0/24",
+		"description  \"two  spaces\"":         "description \"two  spaces\"",
+		"description \"quote \\\" in  value\"": "description \"quote \\\" in  value\"",
+	}
+	for line, want := range tests {
+		if got := rawConfigLineNormalize(line); got != want {
+			t.Errorf("rawConfigLineNormalize(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestRawConfigTextLines(t *testing.T) {
+	text := `
+/* community for monitoring */
+authorization read-only;
+clients {
+    192.0.2.0/24;
+    198.51.100.0/24 restrict;
+}
+# empty container
+view  "all  view" {
+}
+routing-instance RI {
+    clients [ 203.0.113.1/32 "203.0.113.2/32" ];
+}
+`
+	want := []string{
+		"authorization read-only",
+		"clients 192.0.2.0/24",
+		"clients 198.51.100.0/24 restrict",
+		"view \"all  view\"",
+		"routing-instance RI clients 203.0.113.1/32",
+		"routing-instance RI clients \"203.0.113.2/32\"",
+	}
+	lines, err := rawConfigTextLines(text)
+	if err != nil {
+		t.Fatalf("rawConfigTextLines: %s", err)
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("rawConfigTextLines = %q, want %q", lines, want)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"/* only comment */",
+		"authorization read-only",
+		"clients { 192.0.2.0/24; ",
+		"clients 192.0.2.0/24; }",
+		"clients [ 192.0.2.0/24 ;",
+		"{ authorization read-only; }",
+		"description \"no end;",
+		"inactive: authorization read-only;",
+	} {
+		if _, err := rawConfigTextLines(invalid); err == nil {
+			t.Errorf("rawConfigTextLines(%q): want an error", invalid)
+		}
+	}
+}
+
+func TestRawConfigXMLLines(t *testing.T) {
+	snippet := `
+<authorization>read-only</authorization>
+<clients>
+    <name>192.0.2.0/24</name>
+</clients>
+<clients>
+    <name>198.51.100.0/24</name>
+    <restrict/>
+</clients>
+<routing-instance>
+    <name>RI</name>
+    <description>two words</description>
+</routing-instance>
+`
+	want := []string{
+		"authorization read-only",
+		"clients 192.0.2.0/24",
+		"clients 198.51.100.0/24 restrict",
+		"routing-instance RI description \"two words\"",
+	}
+	lines, err := rawConfigXMLLines(snippet)
+	if err != nil {
+		t.Fatalf("rawConfigXMLLines: %s", err)
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("rawConfigXMLLines = %q, want %q", lines, want)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"<authorization>read-only",
+		"<authorization inactive=\"inactive\">read-only</authorization>",
+	} {
+		if _, err := rawConfigXMLLines(invalid); err == nil {
+			t.Errorf("rawConfigXMLLines(%q): want an error", invalid)
+		}
+	}
+}
+
+func TestRawConfigPathValidate(t *testing.T) {
+	validate := resourceRawConfig().Schema["path"].ValidateFunc
+	tests := map[string]bool{
+		"snmp community public":                      true,
+		"snmp community public | display set":        false,
+		"snmp | except community":                    false,
+		"set snmp community public":                  false,
+		"snmp community public\nauthorization write": false,
+	}
+	for path, valid := range tests {
+		if _, errs := validate(path, "path"); (len(errs) == 0) != valid {
+			t.Errorf("validate path %q = %v, want valid %v", path, errs, valid)
+		}
+	}
+}
+
+func TestRawConfigDiff(t *testing.T) {
+	res := resourceRawConfig()
+	stateLines := []interface{}{"authorization read-only", "clients 192.0.2.0/24"}
+	tests := map[string]struct {
+		config   map[string]interface{}
+		wantDiff bool
+	}{
+		"lines_other_order_and_spaces": {
+			config: map[string]interface{}{
+				"lines": []interface{}{"clients  192.0.2.0/24", "authorization read-only"},
+			},
+		},
+		"lines_changed": {
+			config: map[string]interface{}{
+				"lines": []interface{}{"authorization read-only", "clients 198.51.100.0/24"},
+			},
+			wantDiff: true,
+		},
+		"text": {
+			config: map[string]interface{}{
+				"text": "clients {\n  192.0.2.0/24;\n}\nauthorization read-only;\n",
+			},
+		},
+		"text_changed_outside": {
+			config: map[string]interface{}{
+				"text": "authorization read-only;",
+			},
+			wantDiff: true,
+		},
+		"xml": {
+			config: map[string]interface{}{
+				"xml": "<authorization>read-only</authorization><clients><name>192.0.2.0/24</name></clients>",
+			},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			d := res.Data(nil)
+			d.SetId("snmp community public")
+			if err := d.Set("path", "snmp community public"); err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Set("lines", stateLines); err != nil {
+				t.Fatal(err)
+			}
+			for _, k := range []string{"text", "xml"} {
+				if v, ok := tt.config[k]; ok {
+					if err := d.Set(k, v); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			tt.config["path"] = "snmp community public"
+			diff, err := res.SimpleDiff(context.Background(), d.State(),
+				terraform.NewResourceConfigRaw(tt.config), &Session{})
+			if err != nil {
+				t.Fatalf("diff: %s", err)
+			}
+			gotDiff := diff != nil && len(diff.Attributes) > 0
+			if gotDiff != tt.wantDiff {
+				t.Errorf("diff = %v, want a diff %v", diff, tt.wantDiff)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package junos_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccJunosRawConfig_basic(t *testing.T) {
+	if os.Getenv("TESTACC_SWITCH") == "" {
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccJunosRawConfigConfigCreate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_raw_config.testacc_raw", "id", "snmp community testacc_raw"),
+						resource.TestCheckResourceAttr("junos_raw_config.testacc_raw", "lines.#", "1"),
+						resource.TestCheckTypeSetElemAttr("junos_raw_config.testacc_raw", "lines.*",
+							"authorization read-only"),
+					),
+				},
+				{
+					Config: testAccJunosRawConfigConfigUpdate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_raw_config.testacc_raw", "lines.#", "2"),
+						resource.TestCheckTypeSetElemAttr("junos_raw_config.testacc_raw", "lines.*",
+							"authorization read-write"),
+						resource.TestCheckTypeSetElemAttr("junos_raw_config.testacc_raw", "lines.*",
+							"clients 192.0.2.0/24"),
+					),
+				},
+				{
+					ResourceName:      "junos_raw_config.testacc_raw",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config: testAccJunosRawConfigConfigUpdate2(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_raw_config.testacc_raw", "lines.#", "3"),
+						resource.TestCheckTypeSetElemAttr("junos_raw_config.testacc_raw", "lines.*",
+							"clients 198.51.100.0/24 restrict"),
+					),
+				},
+				{
+					Config: testAccJunosRawConfigConfigUpdate3(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_raw_config.testacc_raw", "lines.#", "2"),
+						resource.TestCheckTypeSetElemAttr("junos_raw_config.testacc_raw", "lines.*",
+							"authorization read-only"),
+					),
+				},
+			},
+		})
+	}
+}
+
+func testAccJunosRawConfigConfigCreate() string {
+	return `
+resource "junos_raw_config" "testacc_raw" {
+  path  = "snmp community testacc_raw"
+  lines = ["authorization read-only"]
+}
+`
+}
+func testAccJunosRawConfigConfigUpdate() string {
+	return `
+resource "junos_raw_config" "testacc_raw" {
+  path = "snmp community testacc_raw"
+  lines = [
+    "clients  192.0.2.0/24",
+    "authorization read-write",
+  ]
+}
+`
+}
+func testAccJunosRawConfigConfigUpdate2() string {
+	return `
+resource "junos_raw_config" "testacc_raw" {
+  path = "snmp community testacc_raw"
+  text = <<EOT
+authorization read-write;
+clients {
+    192.0.2.0/24;
+    198.51.100.0/24 restrict;
+}
+EOT
+}
+`
+}
+func testAccJunosRawConfigConfigUpdate3() string {
+	return `
+resource "junos_raw_config" "testacc_raw" {
+  path = "snmp community testacc_raw"
+  xml  = "<authorization>read-only</authorization><clients><name>192.0.2.0/24</name></clients>"
+}
+`
+}
@@ -21,8 +21,9 @@ func resourceSecurityPolicyTunnelPairPolicy() *schema.Resource {
 		CreateContext: resourceSecurityPolicyTunnelPairPolicyCreate,
 		ReadContext:   resourceSecurityPolicyTunnelPairPolicyRead,
 		DeleteContext: resourceSecurityPolicyTunnelPairPolicyDelete,
+		CustomizeDiff: resourceSecurityPolicyTunnelPairPolicyCommitCheck,
 		Importer: &schema.ResourceImporter{
-			State: resourceSecurityPolicyTunnelPairPolicyImport,
+			StateContext: resourceSecurityPolicyTunnelPairPolicyImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"zone_a": {
@@ -56,7 +57,7 @@ func resourceSecurityPolicyTunnelPairPolicy() *schema.Resource {
 func resourceSecurityPolicyTunnelPairPolicyCreate(
 	ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -65,57 +66,68 @@ func resourceSecurityPolicyTunnelPairPolicyCreate(
 		return diag.FromErr(fmt.Errorf("security policy tunnel pair policy not compatible with Junos device %s",
 			jnprSess.SystemInformation.HardwareModel))
 	}
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	securityPolicyExists, err := checkSecurityPolicyExists(d.Get("zone_a").(string), d.Get("zone_b").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if !securityPolicyExists {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("security policy from %v to %v not exists",
-			d.Get("zone_a").(string), d.Get("zone_b").(string)))
+		return append(diag.FromErr(fmt.Errorf("security policy from %v to %v not exists",
+			d.Get("zone_a").(string), d.Get("zone_b").(string))), diag.FromErr(clearErr)...)
 	}
 	securityPolicyExists, err = checkSecurityPolicyExists(d.Get("zone_b").(string), d.Get("zone_a").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	if !securityPolicyExists {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("security policy from %v to %v not exists",
-			d.Get("zone_b").(string), d.Get("zone_a").(string)))
+		return append(diag.FromErr(fmt.Errorf("security policy from %v to %v not exists",
+			d.Get("zone_b").(string), d.Get("zone_a").(string))), diag.FromErr(clearErr)...)
 	}
 	pairPolicyExists, err := checkSecurityPolicyPairExists(d.Get("zone_a").(string), d.Get("policy_a_to_b").(string),
 		d.Get("zone_b").(string), d.Get("policy_b_to_a").(string), m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
+	var diagWarns diag.Diagnostics
 	if pairPolicyExists {
-		sess.configClear(jnprSess)
-
-		return diag.FromErr(fmt.Errorf("security policy pair policy %v(%v) / %v(%v) already exists",
+		existsErr := fmt.Errorf("security policy pair policy %v(%v) / %v(%v) already exists",
 			d.Get("zone_a").(string), d.Get("policy_a_to_b").(string),
-			d.Get("zone_b").(string), d.Get("policy_b_to_a").(string)))
+			d.Get("zone_b").(string), d.Get("policy_b_to_a").(string))
+		if !sess.adoptExisting(d) {
+			clearErr := sess.configClear(jnprSess)
+
+			return append(diag.FromErr(existsErr), diag.FromErr(clearErr)...)
+		}
+		if err := delSecurityPolicyTunnelPairPolicy(d, m, jnprSess); err != nil {
+			clearErr := sess.configClear(jnprSess)
+
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+		}
+		diagWarns = append(diagWarns, adoptedWarning("junos_security_policy_tunnel_pair_policy", existsErr))
 	}
 	if err := setSecurityPolicyTunnelPairPolicy(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
-	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("create resource junos_security_policy_tunnel_pair_policy", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	pairPolicyExists, err = checkSecurityPolicyPairExists(d.Get("zone_a").(string), d.Get("policy_a_to_b").(string),
 		d.Get("zone_b").(string), d.Get("policy_b_to_a").(string), m, jnprSess)
@@ -135,7 +147,7 @@ func resourceSecurityPolicyTunnelPairPolicyCreate(
 func resourceSecurityPolicyTunnelPairPolicyRead(
 	ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -145,12 +157,10 @@ func resourceSecurityPolicyTunnelPairPolicyRead(
 }
 func resourceSecurityPolicyTunnelPairPolicyReadWJnprSess(
 	d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
-	mutex.Lock()
 	policyPairPolicyOptions, err := readSecurityPolicyTunnelPairPolicy(d.Get("zone_a").(string)+idSeparator+
 		d.Get("policy_a_to_b").(string)+idSeparator+
 		d.Get("zone_b").(string)+idSeparator+
 		d.Get("policy_b_to_a").(string), m, jnprSess)
-	mutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -165,32 +175,34 @@ func resourceSecurityPolicyTunnelPairPolicyReadWJnprSess(
 func resourceSecurityPolicyTunnelPairPolicyDelete(
 	ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delSecurityPolicyTunnelPairPolicy(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("delete resource junos_security_policy_tunnel_pair_policy", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 
 	return diagWarns
 }
-func resourceSecurityPolicyTunnelPairPolicyImport(d *schema.ResourceData,
+func resourceSecurityPolicyTunnelPairPolicyImport(ctx context.Context, d *schema.ResourceData,
 	m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -220,6 +232,14 @@ func resourceSecurityPolicyTunnelPairPolicyImport(d *schema.ResourceData,
 
 	return result, nil
 }
+func resourceSecurityPolicyTunnelPairPolicyCommitCheck(
+	ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	return customizeDiffCommitCheck(ctx, "junos_security_policy_tunnel_pair_policy",
+		resourceSecurityPolicyTunnelPairPolicy, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			return setSecurityPolicyTunnelPairPolicy(d, m, jnprSess)
+		})
+}
 
 func checkSecurityPolicyPairExists(zoneA, policyAtoB, zoneB, policyBtoA string,
 	m interface{}, jnprSess *NetconfObject) (bool, error) {
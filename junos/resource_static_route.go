@@ -35,14 +35,43 @@ type staticRouteOptions struct {
 	qualifiedNextHop []map[string]interface{}
 }
 
+// staticRouteXML : static route in the configuration read with get-configuration.
+type staticRouteXML struct {
+	Active           xmlFlag        `xml:"active"`
+	Discard          xmlFlag        `xml:"discard"`
+	Install          xmlFlag        `xml:"install"`
+	NoInstall        xmlFlag        `xml:"no-install"`
+	Passive          xmlFlag        `xml:"passive"`
+	Readvertise      xmlFlag        `xml:"readvertise"`
+	NoReadvertise    xmlFlag        `xml:"no-readvertise"`
+	Receive          xmlFlag        `xml:"receive"`
+	Reject           xmlFlag        `xml:"reject"`
+	Resolve          xmlFlag        `xml:"resolve"`
+	NoResolve        xmlFlag        `xml:"no-resolve"`
+	Retain           xmlFlag        `xml:"retain"`
+	NoRetain         xmlFlag        `xml:"no-retain"`
+	Preference       xmlMetricValue `xml:"preference"`
+	Metric           xmlMetricValue `xml:"metric"`
+	NextTable        string         `xml:"next-table"`
+	Community        []string       `xml:"community"`
+	NextHop          []string       `xml:"next-hop"`
+	QualifiedNextHop []struct {
+		Name       string         `xml:"name"`
+		Interface  string         `xml:"interface"`
+		Preference xmlMetricValue `xml:"preference"`
+		Metric     xmlMetricValue `xml:"metric"`
+	} `xml:"qualified-next-hop"`
+}
+
 func resourceStaticRoute() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceStaticRouteCreate,
 		ReadContext:   resourceStaticRouteRead,
 		UpdateContext: resourceStaticRouteUpdate,
 		DeleteContext: resourceStaticRouteDelete,
+		CustomizeDiff: resourceStaticRouteCommitCheck,
 		Importer: &schema.ResourceImporter{
-			State: resourceStaticRouteImport,
+			StateContext: resourceStaticRouteImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"destination": {
@@ -178,50 +207,63 @@ func resourceStaticRoute() *schema.Resource {
 
 func resourceStaticRouteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if d.Get("routing_instance").(string) != defaultWord {
 		instanceExists, err := checkRoutingInstanceExists(d.Get("routing_instance").(string), m, jnprSess)
 		if err != nil {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(err)
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 		}
 		if !instanceExists {
-			sess.configClear(jnprSess)
+			clearErr := sess.configClear(jnprSess)
 
-			return diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string)))
+			return append(diag.FromErr(fmt.Errorf("routing instance %v doesn't exist", d.Get("routing_instance").(string))),
+				diag.FromErr(clearErr)...)
 		}
 	}
 	staticRouteExists, err := checkStaticRouteExists(d.Get("destination").(string), d.Get("routing_instance").(string),
 		m, jnprSess)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
+	var diagWarns diag.Diagnostics
 	if staticRouteExists {
-		sess.configClear(jnprSess)
+		existsErr := fmt.Errorf("static route %v already exists on table %s",
+			d.Get("destination").(string), d.Get("routing_instance").(string))
+		if !sess.adoptExisting(d) {
+			clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(fmt.Errorf("static route %v already exists on table %s",
-			d.Get("destination").(string), d.Get("routing_instance").(string)))
+			return append(diag.FromErr(existsErr), diag.FromErr(clearErr)...)
+		}
+		if err := delStaticRoute(d.Get("destination").(string), d.Get("routing_instance").(string),
+			m, jnprSess); err != nil {
+			clearErr := sess.configClear(jnprSess)
+
+			return append(diag.FromErr(err), diag.FromErr(clearErr)...)
+		}
+		diagWarns = append(diagWarns, adoptedWarning("junos_static_route", existsErr))
 	}
 	if err := setStaticRoute(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
-	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("create resource junos_static_route", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 	staticRouteExists, err = checkStaticRouteExists(d.Get("destination").(string), d.Get("routing_instance").(string),
 		m, jnprSess)
@@ -239,7 +281,7 @@ func resourceStaticRouteCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 func resourceStaticRouteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -248,10 +290,8 @@ func resourceStaticRouteRead(ctx context.Context, d *schema.ResourceData, m inte
 	return resourceStaticRouteReadWJnprSess(d, m, jnprSess)
 }
 func resourceStaticRouteReadWJnprSess(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
-	mutex.Lock()
 	staticRouteOptions, err := readStaticRoute(d.Get("destination").(string), d.Get("routing_instance").(string),
 		m, jnprSess)
-	mutex.Unlock()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -266,30 +306,32 @@ func resourceStaticRouteReadWJnprSess(d *schema.ResourceData, m interface{}, jnp
 func resourceStaticRouteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.Partial(true)
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delStaticRouteOpts(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 
 	if err := setStaticRoute(d, m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("update resource junos_static_route", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 
 	d.Partial(false)
@@ -298,31 +340,34 @@ func resourceStaticRouteUpdate(ctx context.Context, d *schema.ResourceData, m in
 }
 func resourceStaticRouteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(ctx, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := delStaticRoute(d.Get("destination").(string), d.Get("routing_instance").(string), m, jnprSess); err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return diag.FromErr(err)
+		return append(diag.FromErr(err), diag.FromErr(clearErr)...)
 	}
 	var diagWarns diag.Diagnostics
 	warns, err := sess.commitConf("delete resource junos_static_route", jnprSess)
 	appendDiagWarns(&diagWarns, warns)
 	if err != nil {
-		sess.configClear(jnprSess)
+		clearErr := sess.configClear(jnprSess)
 
-		return append(diagWarns, diag.FromErr(err)...)
+		return append(append(diagWarns, diag.FromErr(err)...), diag.FromErr(clearErr)...)
 	}
 
 	return diagWarns
 }
-func resourceStaticRouteImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceStaticRouteImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -350,6 +395,18 @@ func resourceStaticRouteImport(d *schema.ResourceData, m interface{}) ([]*schema
 
 	return result, nil
 }
+func resourceStaticRouteCommitCheck(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	return customizeDiffCommitCheck(ctx, "junos_static_route", resourceStaticRoute, diff, m,
+		func(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+			if d.Id() != "" {
+				if err := delStaticRouteOpts(d, m, jnprSess); err != nil {
+					return err
+				}
+			}
+
+			return setStaticRoute(d, m, jnprSess)
+		})
+}
 
 func checkStaticRouteExists(destination string, instance string, m interface{}, jnprSess *NetconfObject) (bool, error) {
 	sess := m.(*Session)
@@ -495,116 +552,62 @@ func readStaticRoute(destination string, instance string, m interface{},
 	jnprSess *NetconfObject) (staticRouteOptions, error) {
 	sess := m.(*Session)
 	var confRead staticRouteOptions
-	var destinationConfig string
-	var err error
 
-	if instance == defaultWord {
-		if !strings.Contains(destination, ":") {
-			destinationConfig, err = sess.command("show configuration routing-options "+
-				"static route "+destination+" | display set relative", jnprSess)
-		} else {
-			destinationConfig, err = sess.command("show configuration routing-options rib inet6.0 "+
-				"static route "+destination+" | display set relative", jnprSess)
-		}
-	} else {
-		if !strings.Contains(destination, ":") {
-			destinationConfig, err = sess.command("show configuration routing-instances "+instance+
-				" routing-options static route "+destination+" | display set relative", jnprSess)
-		} else {
-			destinationConfig, err = sess.command("show configuration routing-instances "+instance+
-				" routing-options rib "+instance+".inet6.0 "+
-				"static route "+destination+" | display set relative", jnprSess)
+	path := []configElement{{name: "routing-options"}}
+	if strings.Contains(destination, ":") {
+		rib := "inet6.0"
+		if instance != defaultWord {
+			rib = instance + ".inet6.0"
 		}
+		path = append(path, configElement{name: "rib", key: rib})
 	}
-	if err != nil {
+	path = append(path, configElement{name: "static"}, configElement{name: "route", key: destination})
+	if instance != defaultWord {
+		path = append([]configElement{{name: "routing-instances"}, {name: "instance", key: instance}}, path...)
+	}
+	var route staticRouteXML
+	found, err := sess.readConfigXML(path, &route, jnprSess)
+	if err != nil || !found {
 		return confRead, err
 	}
-
-	if destinationConfig != emptyWord {
-		confRead.destination = destination
-		confRead.routingInstance = instance
-		for _, item := range strings.Split(destinationConfig, "\n") {
-			if strings.Contains(item, "<configuration-output>") {
-				continue
-			}
-			if strings.Contains(item, "</configuration-output>") {
-				break
-			}
-			itemTrim := strings.TrimPrefix(item, setLineStart)
-			switch {
-			case itemTrim == "active":
-				confRead.active = true
-			case strings.HasPrefix(itemTrim, "community "):
-				confRead.community = append(confRead.community, strings.TrimPrefix(itemTrim, "community "))
-			case itemTrim == discardW:
-				confRead.discard = true
-			case itemTrim == "install":
-				confRead.install = true
-			case itemTrim == "no-install":
-				confRead.noInstall = true
-			case strings.HasPrefix(itemTrim, "metric "):
-				confRead.metric, err = strconv.Atoi(strings.TrimPrefix(itemTrim, "metric "))
-				if err != nil {
-					return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrim, err)
-				}
-			case strings.HasPrefix(itemTrim, "next-hop "):
-				confRead.nextHop = append(confRead.nextHop, strings.TrimPrefix(itemTrim, "next-hop "))
-			case strings.HasPrefix(itemTrim, "next-table "):
-				confRead.nextTable = strings.TrimPrefix(itemTrim, "next-table ")
-			case itemTrim == passiveW:
-				confRead.passive = true
-			case strings.HasPrefix(itemTrim, "preference "):
-				confRead.preference, err = strconv.Atoi(strings.TrimPrefix(itemTrim, "preference "))
-				if err != nil {
-					return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrim, err)
-				}
-			case strings.HasPrefix(itemTrim, "qualified-next-hop "):
-				nextHop := strings.TrimPrefix(itemTrim, "qualified-next-hop ")
-				nextHopWords := strings.Split(nextHop, " ")
-				qualifiedNextHopOptions := map[string]interface{}{
-					"next_hop":   nextHopWords[0],
-					"interface":  "",
-					"metric":     0,
-					"preference": 0,
-				}
-				qualifiedNextHopOptions, confRead.qualifiedNextHop = copyAndRemoveItemMapList("next_hop",
-					false, qualifiedNextHopOptions, confRead.qualifiedNextHop)
-				itemTrimQnh := strings.TrimPrefix(itemTrim, "qualified-next-hop "+nextHopWords[0]+" ")
-				switch {
-				case strings.HasPrefix(itemTrimQnh, "interface "):
-					qualifiedNextHopOptions["interface"] = strings.TrimPrefix(itemTrimQnh, "interface ")
-				case strings.HasPrefix(itemTrimQnh, "metric "):
-					qualifiedNextHopOptions["metric"], err = strconv.Atoi(
-						strings.TrimPrefix(itemTrimQnh, "metric "))
-					if err != nil {
-						return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimQnh, err)
-					}
-				case strings.HasPrefix(itemTrimQnh, "preference "):
-					qualifiedNextHopOptions["preference"], err = strconv.Atoi(
-						strings.TrimPrefix(itemTrimQnh, "preference "))
-					if err != nil {
-						return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimQnh, err)
-					}
-				}
-				confRead.qualifiedNextHop = append(confRead.qualifiedNextHop, qualifiedNextHopOptions)
-			case itemTrim == "readvertise":
-				confRead.readvertise = true
-			case itemTrim == "no-readvertise":
-				confRead.noReadvertise = true
-			case itemTrim == "receive":
-				confRead.receive = true
-			case itemTrim == "reject":
-				confRead.reject = true
-			case itemTrim == "resolve":
-				confRead.resolve = true
-			case itemTrim == "no-resolve":
-				confRead.noResolve = true
-			case itemTrim == "retain":
-				confRead.retain = true
-			case itemTrim == "no-retain":
-				confRead.noRetain = true
-			}
+	confRead.destination = destination
+	confRead.routingInstance = instance
+	confRead.active = bool(route.Active)
+	confRead.discard = bool(route.Discard)
+	confRead.install = bool(route.Install)
+	confRead.noInstall = bool(route.NoInstall)
+	confRead.passive = bool(route.Passive)
+	confRead.readvertise = bool(route.Readvertise)
+	confRead.noReadvertise = bool(route.NoReadvertise)
+	confRead.receive = bool(route.Receive)
+	confRead.reject = bool(route.Reject)
+	confRead.resolve = bool(route.Resolve)
+	confRead.noResolve = bool(route.NoResolve)
+	confRead.retain = bool(route.Retain)
+	confRead.noRetain = bool(route.NoRetain)
+	if confRead.preference, err = route.Preference.Int(); err != nil {
+		return confRead, err
+	}
+	if confRead.metric, err = route.Metric.Int(); err != nil {
+		return confRead, err
+	}
+	confRead.nextTable = route.NextTable
+	confRead.community = route.Community
+	confRead.nextHop = route.NextHop
+	for _, qnh := range route.QualifiedNextHop {
+		qualifiedNextHopOptions := map[string]interface{}{
+			"next_hop":   qnh.Name,
+			"interface":  qnh.Interface,
+			"metric":     0,
+			"preference": 0,
+		}
+		if qualifiedNextHopOptions["metric"], err = qnh.Metric.Int(); err != nil {
+			return confRead, err
+		}
+		if qualifiedNextHopOptions["preference"], err = qnh.Preference.Int(); err != nil {
+			return confRead, err
 		}
+		confRead.qualifiedNextHop = append(confRead.qualifiedNextHop, qualifiedNextHopOptions)
 	}
 
 	return confRead, nil
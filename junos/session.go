@@ -1,30 +1,217 @@
 package junos
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+var (
+	netconfPools      = make([]*netconfPool, 0)
+	netconfPoolsMutex = &sync.Mutex{}
 )
 
 // Session information to connect on Junos Device.
 type Session struct {
-	junosPort           int
-	junosSleepLock      int
-	junosSleepShort     int
-	junosSleepSSHClosed int
-	junosIP             string
-	junosUserName       string
-	junosPassword       string
-	junosSSHKeyPEM      string
-	junosSSHKeyFile     string
-	junosKeyPass        string
-	junosGroupIntDel    string
-	junosLogFile        string
-}
-
-func (sess *Session) startNewSession() (*NetconfObject, error) {
+	junosSSHInsecure          bool
+	junosCommitCheckPlan      bool
+	junosConfigPrivate        bool
+	junosDiffPlan             bool
+	junosAdoptExisting        bool
+	junosPort                 int
+	junosCommitConfirmed      int
+	junosLockTimeout          int
+	junosRetryCount           int
+	junosRetryBackoff         int
+	junosSleepLock            int
+	junosSleepShort           int
+	junosSleepSSHClosed       int
+	junosIP                   string
+	junosUserName             string
+	junosPassword             string
+	junosSSHKeyPEM            string
+	junosSSHKeyFile           string
+	junosKeyPass              string
+	junosGroupIntDel          string
+	junosLogicalSystem        string
+	junosTenant               string
+	junosConfigGroup          string
+	junosSSHKnownHosts        string
+	junosCommitConfirmedCheck string
+	junosDiffAuditFile        string
+	junosSSHFingerprints      []string
+	devices                   map[string]*Session
+	logger                    hclog.Logger
+	junosBastion              *netconfBastion
+	netconfPool               *netconfPool
+	commitBatcher             *commitBatcher
+	configSnapshot            *configSnapshot
+	control                   *deviceControl
+}
+
+// configClearError : errors of the steps to clear the candidate configuration.
+type configClearError struct {
+	clearErr  error
+	unlockErr error
+	closeErr  error
+}
+
+func (e *configClearError) Error() string {
+	messages := make([]string, 0)
+	if e.clearErr != nil {
+		messages = append(messages, "failed to clear candidate configuration : "+e.clearErr.Error())
+	}
+	if e.unlockErr != nil {
+		messages = append(messages, "failed to unlock candidate configuration : "+e.unlockErr.Error())
+	}
+	if e.closeErr != nil {
+		messages = append(messages, "failed to close session : "+e.closeErr.Error())
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// Unwrap returns the error of the first failed step.
+func (e *configClearError) Unwrap() error {
+	if e.clearErr != nil {
+		return e.clearErr
+	}
+	if e.unlockErr != nil {
+		return e.unlockErr
+	}
+
+	return e.closeErr
+}
+
+// netconfPool : bounded pool of netconf sessions reused between actions.
+type netconfPool struct {
+	closed bool
+	mutex  sync.Mutex
+	slots  chan struct{}
+	idle   []*NetconfObject
+}
+
+func newNetconfPool(size int) *netconfPool {
+	pool := &netconfPool{
+		slots: make(chan struct{}, size),
+		idle:  make([]*NetconfObject, 0, size),
+	}
+	netconfPoolsMutex.Lock()
+	netconfPools = append(netconfPools, pool)
+	netconfPoolsMutex.Unlock()
+
+	return pool
+}
+
+// CloseSessions closes all netconf sessions kept open for reuse by providers.
+func CloseSessions() {
+	netconfPoolsMutex.Lock()
+	defer netconfPoolsMutex.Unlock()
+	for _, pool := range netconfPools {
+		pool.close(0)
+	}
+	netconfPools = netconfPools[:0]
+}
+
+// take waits for a free slot (or the end of ctx) and returns an idle session if available.
+func (pool *netconfPool) take(ctx context.Context) (*NetconfObject, error) {
+	select {
+	case pool.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to wait a free netconf session : %w", ctx.Err())
+	}
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if len(pool.idle) == 0 {
+		return nil, nil
+	}
+	jnpr := pool.idle[len(pool.idle)-1]
+	pool.idle = pool.idle[:len(pool.idle)-1]
+
+	return jnpr, nil
+}
+
+// put releases the slot and keeps the session for reuse, return false if the pool doesn't accept it
+// (a session still keeping the commit slot of device is not accepted to be closed and release it).
+func (pool *netconfPool) put(jnpr *NetconfObject) bool {
+	defer pool.release()
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if pool.closed || jnpr.broken || jnpr.locked || jnpr.private || jnpr.commitSlot != nil {
+		return false
+	}
+	pool.idle = append(pool.idle, jnpr)
+
+	return true
+}
+
+func (pool *netconfPool) release() {
+	<-pool.slots
+}
+
+// close closes idle sessions and sessions released after.
+func (pool *netconfPool) close(sleepClosed int) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	pool.closed = true
+	for _, jnpr := range pool.idle {
+		_ = jnpr.Close(sleepClosed)
+	}
+	pool.idle = pool.idle[:0]
+}
+
+// startNewSession returns a netconf session (reused from the pool or new)
+// with its RPCs interrupted at the end of ctx.
+func (sess *Session) startNewSession(ctx context.Context) (*NetconfObject, error) {
+	if sess.netconfPool == nil {
+		return sess.dialNewSession(ctx)
+	}
+	for {
+		jnpr, err := sess.netconfPool.take(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if jnpr == nil {
+			break
+		}
+		jnpr.ctx = ctx
+		jnpr.logger = sess.log()
+		// check that the session is still alive before reuse it
+		err = jnpr.gatherFacts()
+		if err == nil {
+			jnpr.log().Debug("reuse netconf session")
+
+			return jnpr, nil
+		}
+		jnpr.log().Debug("drop dead netconf session", "error", err)
+		_ = jnpr.Close(0)
+		sess.netconfPool.release()
+	}
+	jnpr, err := sess.dialNewSession(ctx)
+	if err != nil {
+		if jnpr != nil {
+			_ = jnpr.Close(sess.junosSleepSSHClosed)
+		}
+		sess.netconfPool.release()
+
+		return nil, err
+	}
+
+	return jnpr, nil
+}
+
+// dialNewSession opens a new netconf session with its RPCs interrupted at the end of ctx.
+func (sess *Session) dialNewSession(ctx context.Context) (*NetconfObject, error) {
+	if sess.junosIP == "" {
+		return nil, fmt.Errorf("ip not set in provider configuration, set it or the device argument")
+	}
 	var auth netconfAuthMethod
 	auth.Username = sess.junosUserName
 	if sess.junosSSHKeyPEM != "" {
@@ -34,13 +221,10 @@ func (sess *Session) startNewSession() (*NetconfObject, error) {
 		}
 	}
 	if sess.junosSSHKeyFile != "" {
-		auth.PrivateKeyFile = sess.junosSSHKeyFile
-		if strings.HasPrefix(sess.junosSSHKeyFile, "~") {
-			homeDir, err := os.UserHomeDir()
-			if err != nil {
-				return nil, fmt.Errorf("failed to read user home directory : %w", err)
-			}
-			auth.PrivateKeyFile = homeDir + sess.junosSSHKeyFile[1:]
+		var err error
+		auth.PrivateKeyFile, err = replaceTildeToHomeDir(sess.junosSSHKeyFile)
+		if err != nil {
+			return nil, err
 		}
 		if sess.junosKeyPass != "" {
 			auth.Passphrase = sess.junosKeyPass
@@ -49,40 +233,131 @@ func (sess *Session) startNewSession() (*NetconfObject, error) {
 	if sess.junosPassword != "" {
 		auth.Password = sess.junosPassword
 	}
-	jnpr, err := netconfNewSession(sess.junosIP+":"+strconv.Itoa(sess.junosPort), &auth)
+	auth.InsecureIgnoreHostKey = sess.junosSSHInsecure
+	auth.HostKeyFingerprints = sess.junosSSHFingerprints
+	if sess.junosSSHKnownHosts != "" {
+		var err error
+		auth.KnownHostsFile, err = replaceTildeToHomeDir(sess.junosSSHKnownHosts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var bastion *netconfBastion
+	if sess.junosBastion != nil {
+		// copy to not share the authentication state between parallel connections
+		bastionCopy := *sess.junosBastion
+		var err error
+		bastionCopy.Auth.PrivateKeyFile, err = replaceTildeToHomeDir(bastionCopy.Auth.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		bastionCopy.Auth.KnownHostsFile, err = replaceTildeToHomeDir(bastionCopy.Auth.KnownHostsFile)
+		if err != nil {
+			return nil, err
+		}
+		bastion = &bastionCopy
+	}
+	jnpr, err := netconfNewSessionContext(ctx, sess.junosIP+":"+strconv.Itoa(sess.junosPort), &auth, bastion)
 	if err != nil {
 		return nil, err
 	}
 	if jnpr.SystemInformation.HardwareModel == "" {
 		return jnpr, fmt.Errorf("can't read model of device with <get-system-information/> netconf command")
 	}
-	if sess.junosLogFile != "" {
-		logFile("[startNewSession] started", sess.junosLogFile)
-	}
+	jnpr.logger = sess.log()
+	jnpr.log().Debug("start netconf session",
+		"hardware_model", jnpr.SystemInformation.HardwareModel, "os_version", jnpr.SystemInformation.OsVersion)
 
 	return jnpr, nil
 }
+
+// configPathPrefix returns the configuration path (with a trailing space) of the configuration group,
+// logical system or tenant where resources are configured, empty if not set.
+func (sess *Session) configPathPrefix() string {
+	prefix := ""
+	if sess.junosConfigGroup != "" {
+		prefix = "groups " + sess.junosConfigGroup + " "
+	}
+	if sess.junosLogicalSystem != "" {
+		return prefix + "logical-systems " + sess.junosLogicalSystem + " "
+	}
+	if sess.junosTenant != "" {
+		return prefix + "tenants " + sess.junosTenant + " "
+	}
+
+	return prefix
+}
+
+// replaceTildeToHomeDir replaces the ~ prefix of a path by the user home directory.
+func replaceTildeToHomeDir(path string) (string, error) {
+	if !strings.HasPrefix(path, "~") {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to read user home directory : %w", err)
+	}
+
+	return homeDir + path[1:], nil
+}
 func (sess *Session) closeSession(jnpr *NetconfObject) {
-	err := jnpr.Close(sess.junosSleepSSHClosed)
-	if sess.junosLogFile != "" {
-		if err != nil {
-			logFile(fmt.Sprintf("[closeSession] err: %q", err), sess.junosLogFile)
-		} else {
-			logFile("[closeSession] closed", sess.junosLogFile)
+	sess.stopBatching(jnpr)
+	jnpr.loadedLines = nil
+	if sess.netconfPool != nil {
+		if jnpr.locked && !jnpr.broken {
+			if err := jnpr.netconfConfigUnlock(); err != nil {
+				jnpr.log().Warn("failed to unlock candidate configuration", "error", err)
+			}
+		}
+		if jnpr.private && !jnpr.broken {
+			if err := jnpr.netconfConfigClose(); err != nil {
+				jnpr.log().Warn("failed to close private configuration", "error", err)
+			}
+		}
+		if sess.netconfPool.put(jnpr) {
+			jnpr.log().Debug("release netconf session")
+
+			return
 		}
 	}
+	if err := jnpr.Close(sess.junosSleepSSHClosed); err != nil {
+		jnpr.log().Warn("failed to close netconf session", "error", err)
+	} else {
+		jnpr.log().Debug("close netconf session")
+	}
 }
 func (sess *Session) command(cmd string, jnpr *NetconfObject) (string, error) {
-	read, err := jnpr.netconfCommand(cmd)
-	if sess.junosLogFile != "" {
-		logFile(fmt.Sprintf("[command] cmd: %q", cmd), sess.junosLogFile)
-		logFile(fmt.Sprintf("[command] read: %q", read), sess.junosLogFile)
+	prefix := sess.configPathPrefix()
+	if prefix != "" && strings.HasPrefix(cmd, "show configuration ") {
+		cmd = "show configuration " + prefix + strings.TrimPrefix(cmd, "show configuration ")
+	}
+	var read string
+	run := func() error {
+		var err error
+		read, err = jnpr.netconfCommand(cmd)
+
+		return err
 	}
+	var err error
+	snapshot := false
+	if sess.useConfigSnapshot(jnpr) {
+		read, snapshot, err = sess.snapshotCommand(cmd, jnpr)
+	}
+	switch {
+	case snapshot:
+	case checkCommandReadOnly(cmd) == nil:
+		err = sess.readRPC(jnpr, "command", run)
+	default:
+		err = run()
+	}
+	if prefix != "" {
+		// remove the prefix in output of 'display set' without relative
+		read = strings.ReplaceAll(read, setLineStart+prefix, setLineStart)
+	}
+	jnpr.log().Debug("command", "command", cmd, "read", read)
 	sleepShort(sess.junosSleepShort)
 	if err != nil && read != emptyWord {
-		if sess.junosLogFile != "" {
-			logFile(fmt.Sprintf("[command] err: %q", err), sess.junosLogFile)
-		}
+		jnpr.log().Error("command failed", "command", cmd, "error", err)
 
 		return "", err
 	}
@@ -90,16 +365,23 @@ func (sess *Session) command(cmd string, jnpr *NetconfObject) (string, error) {
 	return read, nil
 }
 func (sess *Session) commandXML(cmd string, jnpr *NetconfObject) (string, error) {
-	read, err := jnpr.netconfCommandXML(cmd)
-	if sess.junosLogFile != "" {
-		logFile(fmt.Sprintf("[commandXML] cmd: %q", cmd), sess.junosLogFile)
-		logFile(fmt.Sprintf("[commandXML] read: %q", read), sess.junosLogFile)
+	var read string
+	run := func() error {
+		var err error
+		read, err = jnpr.netconfCommandXML(cmd)
+
+		return err
+	}
+	var err error
+	if checkRPCReadOnly(cmd) == nil {
+		err = sess.readRPC(jnpr, "command xml", run)
+	} else {
+		err = run()
 	}
+	jnpr.log().Debug("command xml", "rpc", rpcMethod(cmd), "command", cmd, "read", read)
 	sleepShort(sess.junosSleepShort)
 	if err != nil {
-		if sess.junosLogFile != "" {
-			logFile(fmt.Sprintf("[commandXML] err: %q", err), sess.junosLogFile)
-		}
+		jnpr.log().Error("command xml failed", "rpc", rpcMethod(cmd), "command", cmd, "error", err)
 
 		return "", err
 	}
@@ -107,95 +389,411 @@ func (sess *Session) commandXML(cmd string, jnpr *NetconfObject) (string, error)
 	return read, nil
 }
 func (sess *Session) configSet(cmd []string, jnpr *NetconfObject) error {
+	if prefix := sess.configPathPrefix(); prefix != "" {
+		cmdPrefixed := make([]string, len(cmd))
+		for i, line := range cmd {
+			switch {
+			case strings.HasPrefix(line, "set "):
+				cmdPrefixed[i] = "set " + prefix + strings.TrimPrefix(line, "set ")
+			case strings.HasPrefix(line, "delete "):
+				cmdPrefixed[i] = "delete " + prefix + strings.TrimPrefix(line, "delete ")
+			default:
+				cmdPrefixed[i] = line
+			}
+		}
+		cmd = cmdPrefixed
+	}
+	if sess.junosConfigGroup != "" {
+		applyGroups := "set apply-groups " + sess.junosConfigGroup
+		for _, line := range cmd {
+			if strings.HasPrefix(line, "set ") {
+				// the configuration of group is inherited only if the group is applied
+				// (once by candidate configuration or batch)
+				if !jnpr.hasConfigLine(applyGroups) {
+					cmd = append(cmd, applyGroups)
+				}
+
+				break
+			}
+		}
+	}
+	if jnpr.batching {
+		jnpr.batchLines = append(jnpr.batchLines, cmd...)
+		jnpr.log().Debug("collect configuration lines for batch commit", "lines", cmd)
+
+		return nil
+	}
+
+	return sess.loadConfig(cmd, jnpr)
+}
+
+// loadConfig loads set/delete lines in the candidate configuration
+// (on a new session with the previous lines after a transport failure).
+func (sess *Session) loadConfig(cmd []string, jnpr *NetconfObject) error {
+	return sess.retryTransport(jnpr, "load configuration", func() error {
+		return sess.loadConfigLines(cmd, jnpr)
+	})
+}
+
+// loadConfigLines loads set/delete lines in the candidate configuration without retry.
+func (sess *Session) loadConfigLines(cmd []string, jnpr *NetconfObject) error {
 	message, err := jnpr.netconfConfigSet(cmd)
 	sleepShort(sess.junosSleepShort)
-	if sess.junosLogFile != "" {
-		logFile(fmt.Sprintf("[configSet] cmd: %q", cmd), sess.junosLogFile)
-		logFile(fmt.Sprintf("[configSet] message: %q", message), sess.junosLogFile)
-	}
+	jnpr.log().Debug("load configuration lines", "lines", cmd, "message", message)
 	if err != nil {
-		if sess.junosLogFile != "" {
-			logFile(fmt.Sprintf("[configSet] err: %q", err), sess.junosLogFile)
-		}
+		jnpr.log().Error("load configuration lines failed", "lines", cmd, "error", err)
 
 		return err
 	}
+	jnpr.loadedLines = append(jnpr.loadedLines, cmd...)
 
 	return nil
 }
+
+// hasConfigLine returns true if line is already collected for the batch commit
+// or loaded in the candidate configuration by the session.
+func (j *NetconfObject) hasConfigLine(line string) bool {
+	for _, lines := range [][]string{j.batchLines, j.loadedLines} {
+		for _, l := range lines {
+			if l == line {
+				return true
+			}
+		}
+	}
+
+	return false
+}
 func (sess *Session) commitConf(logMessage string, jnpr *NetconfObject) (_warnings []error, _err error) {
-	if sess.junosLogFile != "" {
-		logFile(fmt.Sprintf("[commitConf] commit %q", logMessage), sess.junosLogFile)
+	if jnpr.batching {
+		batch, entry := sess.commitBatcher.add(sess, jnpr.batchLines, logMessage, jnpr.batchDeadline)
+		jnpr.batchAdded = true
+		jnpr.batchLines = nil
+		jnpr.log().Debug("wait batch commit", "log_message", logMessage)
+		waitStart := time.Now()
+		warns, err := batch.wait(jnpr.sessionContext(), sess.commitBatcher, sess, entry)
+		if err != nil {
+			jnpr.log().Error("batch commit failed", "log_message", logMessage, "error", err,
+				"duration", time.Since(waitStart).String())
+		} else {
+			jnpr.log().Info("batch commit done", "log_message", logMessage,
+				"duration", time.Since(waitStart).String())
+		}
+
+		return warns, err
 	}
-	warns, err := jnpr.netconfCommit(logMessage)
-	sleepShort(sess.junosSleepShort)
+
+	return sess.commitNow(logMessage, jnpr)
+}
+
+// commitNow commits the candidate configuration (with confirmed if configured).
+func (sess *Session) commitNow(logMessage string, jnpr *NetconfObject) (_warnings []error, _err error) {
+	defer sess.invalidateConfigSnapshot()
+	// with a private candidate configuration, only the commit is exclusive
+	// (with a lock, the commit slot is already kept since the lock)
+	acquired, err := sess.acquireCommit(jnpr.sessionContext(), jnpr)
 	if err != nil {
-		if sess.junosLogFile != "" {
-			logFile(fmt.Sprintf("[commitConf] commit error: %q", err), sess.junosLogFile)
-			if len(warns) > 0 {
-				for _, w := range warns {
-					logFile(fmt.Sprintf("[commitConf] commit warning: %q", w), sess.junosLogFile)
-				}
-			}
+		return []error{}, err
+	}
+	if acquired {
+		defer jnpr.releaseCommit()
+	}
+	jnpr.log().Debug("commit", "log_message", logMessage)
+	commitStart := time.Now()
+	compare := ""
+	if sess.junosDiffAuditFile != "" {
+		compare, err = jnpr.netconfConfigCompare()
+		sleepShort(sess.junosSleepShort)
+		if err != nil {
+			return []error{}, fmt.Errorf("failed to read differences for diff_audit_file : %w", err)
+		}
+	}
+	var warns []error
+	if sess.junosCommitConfirmed > 0 {
+		warns, err = sess.commitConfirmed(logMessage, jnpr)
+	} else {
+		warns, err = sess.commitRetry(jnpr, logMessage, jnpr.netconfCommit)
+		sleepShort(sess.junosSleepShort)
+	}
+	for _, w := range warns {
+		jnpr.log().Warn("commit warning", "log_message", logMessage, "warning", w)
+	}
+	if err != nil {
+		jnpr.log().Error("commit failed", "log_message", logMessage, "error", err,
+			"duration", time.Since(commitStart).String())
+		if jnpr.interrupted {
+			return warns, fmt.Errorf("commit interrupted, the configuration may have been committed "+
+				"(check 'show system commit' on device) : %w", err)
 		}
 
 		return warns, err
 	}
-	if len(warns) > 0 && sess.junosLogFile != "" {
-		for _, w := range warns {
-			logFile(fmt.Sprintf("[commitConf] commit warning: %q", w), sess.junosLogFile)
+	jnpr.log().Info("commit done", "log_message", logMessage, "duration", time.Since(commitStart).String())
+	jnpr.loadedLines = nil
+	if sess.junosDiffAuditFile != "" {
+		if err := sess.writeDiffAudit(logMessage, compare); err != nil {
+			warns = append(warns, err)
 		}
 	}
 
 	return warns, nil
 }
 
-func (sess *Session) configLock(jnpr *NetconfObject) {
-	var lock bool
-	for {
-		lock = jnpr.netconfConfigLock()
-		if lock {
-			if sess.junosLogFile != "" {
-				logFile("[configLock] locked", sess.junosLogFile)
-			}
-			sleepShort(sess.junosSleepShort)
+// commitConfirmed commits the configuration with a rollback if it's not confirmed
+// then checks with a new session that the device is still reachable (and healthy with the health check)
+// before confirm the commit.
+// If the check fails, the commit is not confirmed and the device rollbacks itself.
+func (sess *Session) commitConfirmed(logMessage string, jnpr *NetconfObject) (_warnings []error, _err error) {
+	warns, err := sess.commitRetry(jnpr, logMessage, func(logMessage string) ([]error, error) {
+		return jnpr.netconfCommitConfirmed(logMessage, sess.junosCommitConfirmed)
+	})
+	sleepShort(sess.junosSleepShort)
+	if err != nil {
+		return warns, err
+	}
+	jnpr.log().Debug("commit confirmed, check reconnect", "confirm_timeout", sess.junosCommitConfirmed)
+	jnprCheck, err := sess.dialNewSession(jnpr.sessionContext())
+	if err != nil {
+		if jnprCheck != nil {
+			_ = jnprCheck.Close(sess.junosSleepSSHClosed)
+		}
 
-			break
+		return warns, fmt.Errorf("failed to reconnect after commit confirmed, "+
+			"configuration will be rolled back in %d minute(s) : %w", sess.junosCommitConfirmed, err)
+	}
+	if sess.junosCommitConfirmedCheck != "" {
+		var read string
+		if strings.HasPrefix(sess.junosCommitConfirmedCheck, "<") {
+			read, err = jnprCheck.netconfCommandXML(sess.junosCommitConfirmedCheck)
 		} else {
-			if sess.junosLogFile != "" {
-				logFile("[configLock] sleep for wait lock", sess.junosLogFile)
+			read, err = jnprCheck.netconfCommand(sess.junosCommitConfirmedCheck)
+			if read == emptyWord {
+				err = nil
 			}
-			sleep(sess.junosSleepLock)
+		}
+		sleepShort(sess.junosSleepShort)
+		jnprCheck.log().Debug("commit confirmed health check",
+			"command", sess.junosCommitConfirmedCheck, "read", read, "error", err)
+		if err != nil {
+			_ = jnprCheck.Close(sess.junosSleepSSHClosed)
+
+			return warns, fmt.Errorf("health check failed after commit confirmed, "+
+				"configuration will be rolled back in %d minute(s) : %w", sess.junosCommitConfirmed, err)
 		}
 	}
-}
-func (sess *Session) configClear(jnpr *NetconfObject) {
-	err := jnpr.netconfConfigClear()
+	if err := jnprCheck.Close(sess.junosSleepSSHClosed); err != nil {
+		jnprCheck.log().Warn("failed to close netconf session", "error", err)
+	}
+	warnsConfirm, err := sess.commitRetry(jnpr, logMessage, jnpr.netconfCommit)
 	sleepShort(sess.junosSleepShort)
-	if sess.junosLogFile != "" {
-		logFile("[configClear] config clear", sess.junosLogFile)
+	warns = append(warns, warnsConfirm...)
+	if err != nil {
+		return warns, fmt.Errorf("failed to confirm commit, "+
+			"configuration will be rolled back in %d minute(s) : %w", sess.junosCommitConfirmed, err)
 	}
+
+	return warns, nil
+}
+
+// planCheck loads configuration with stage in a private candidate configuration,
+// reads the differences with the committed configuration (with diff_on_plan),
+// checks it with a commit check (with commit_check_on_plan) and discards the private candidate.
+func (sess *Session) planCheck(ctx context.Context, stage func(jnpr *NetconfObject) error) (
+	_compare string, _warnings []error, _err error) {
+	jnpr, err := sess.startNewSession(ctx)
+	if err != nil {
+		return "", []error{}, err
+	}
+	defer sess.closeSession(jnpr)
+	err = jnpr.netconfConfigOpenPrivate()
+	sleepShort(sess.junosSleepShort)
 	if err != nil {
-		err := jnpr.Close(sess.junosSleepSSHClosed)
-		if err != nil && sess.junosLogFile != "" {
-			logFile(fmt.Sprintf("[configClear] close err: %q", err), sess.junosLogFile)
+		jnpr.log().Error("failed to open private configuration for plan check", "error", err)
+
+		return "", []error{}, err
+	}
+	defer func() {
+		if jnpr.interrupted {
+			return
+		}
+		err := jnpr.netconfConfigClose()
+		sleepShort(sess.junosSleepShort)
+		if err != nil {
+			// private candidate not discarded, session can't be reused
+			jnpr.broken = true
+			jnpr.log().Warn("failed to close private configuration of plan check", "error", err)
 		}
-		panic(err)
+	}()
+	if err := stage(jnpr); err != nil {
+		return "", []error{}, err
 	}
-	err = jnpr.netconfConfigUnlock()
+	compare := ""
+	if sess.junosDiffPlan {
+		compare, err = jnpr.netconfConfigCompare()
+		sleepShort(sess.junosSleepShort)
+		if err != nil {
+			jnpr.log().Error("failed to compare configuration for plan check", "error", err)
+
+			return "", []error{}, err
+		}
+	}
+	if !sess.junosCommitCheckPlan {
+		return compare, []error{}, nil
+	}
+	warns, err := jnpr.netconfCommitCheck()
 	sleepShort(sess.junosSleepShort)
-	if sess.junosLogFile != "" {
-		logFile("[configClear] config unlock", sess.junosLogFile)
+	for _, w := range warns {
+		jnpr.log().Warn("commit check warning", "warning", w)
 	}
 	if err != nil {
-		err := jnpr.Close(sess.junosSleepSSHClosed)
-		if err != nil && sess.junosLogFile != "" {
-			logFile(fmt.Sprintf("[configClear] close err: %q", err), sess.junosLogFile)
+		jnpr.log().Error("commit check failed", "error", err)
+	}
+
+	return compare, warns, err
+}
+
+// stopBatching ends the collect of changes for the batch with jnpr.
+func (sess *Session) stopBatching(jnpr *NetconfObject) {
+	if jnpr.batching && !jnpr.batchAdded && sess.commitBatcher != nil {
+		sess.commitBatcher.leave()
+	}
+	jnpr.batching = false
+	jnpr.batchAdded = false
+	jnpr.batchLines = nil
+}
+
+// configLock locks the candidate configuration with lockCandidate
+// or, with batch_commit, prepares the session to collect the changes for the batch.
+func (sess *Session) configLock(ctx context.Context, jnpr *NetconfObject) error {
+	if sess.commitBatcher != nil {
+		// changes are only collected, the candidate configuration is locked for the commit of the batch
+		sess.stopBatching(jnpr)
+		sess.commitBatcher.begin()
+		jnpr.batching = true
+		jnpr.batchLines = nil
+		jnpr.batchDeadline, _ = ctx.Deadline()
+
+		return nil
+	}
+
+	return sess.lockCandidate(ctx, jnpr)
+}
+
+// lockCandidate locks the candidate configuration (or opens a private candidate configuration
+// with config_database_mode = private).
+// If it's not available, it retries with an exponential backoff (from 1 second to cmd_sleep_lock seconds)
+// until lock_timeout or the deadline of ctx.
+func (sess *Session) lockCandidate(ctx context.Context, jnpr *NetconfObject) error {
+	lockType := "exclusive"
+	lock := jnpr.netconfConfigLock
+	if sess.junosConfigPrivate {
+		lockType = "private"
+		lock = jnpr.netconfConfigOpenPrivate
+	}
+	lockStart := time.Now()
+	if !sess.junosConfigPrivate {
+		// wait the unlock by the other sessions of the provider before try the lock on the device
+		acquired, err := sess.acquireCommit(ctx, jnpr)
+		if err != nil {
+			return err
+		}
+		if acquired {
+			defer func() {
+				if !jnpr.locked {
+					jnpr.releaseCommit()
+				}
+			}()
+		}
+	}
+	var timeout <-chan time.Time
+	if sess.junosLockTimeout > 0 {
+		timer := time.NewTimer(time.Duration(sess.junosLockTimeout) * time.Second)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	maxBackoff := time.Duration(sess.junosSleepLock) * time.Second
+	backoff := time.Second
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	for {
+		err := sess.retryTransport(jnpr, "lock", lock)
+		if err == nil {
+			jnpr.loadedLines = nil
+			jnpr.log().Debug("candidate configuration locked",
+				"lock_type", lockType, "wait", time.Since(lockStart).String())
+			sleepShort(sess.junosSleepShort)
+
+			return nil
+		}
+		// only a lock denied by another session can succeed later
+		var lockErr *lockDeniedError
+		if jnpr.broken || !errors.As(err, &lockErr) {
+			return err
+		}
+		jnpr.log().Info("wait lock of candidate configuration",
+			"lock_type", lockType, "backoff", backoff.String(), "error", err)
+		retry := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			retry.Stop()
+
+			return fmt.Errorf("failed to lock candidate configuration, %s : %w", ctx.Err(), err)
+		case <-timeout:
+			retry.Stop()
+
+			return fmt.Errorf("failed to lock candidate configuration after %d second(s) : %w",
+				sess.junosLockTimeout, err)
+		case <-retry.C:
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
 		}
-		panic(err)
 	}
 }
 
+// configClear discards the changes in the candidate configuration and unlocks it
+// (or closes the private candidate configuration).
+// If a step fails, the next steps are still tried and the session is closed (it can't be reused).
+func (sess *Session) configClear(jnpr *NetconfObject) error {
+	if jnpr.batching {
+		// nothing loaded on the device, drop the collected changes
+		sess.stopBatching(jnpr)
+
+		return nil
+	}
+	jnpr.loadedLines = nil
+	if jnpr.interrupted {
+		// the transport is closed, the end of session discards the changes and releases the lock
+		jnpr.log().Info("netconf session interrupted, changes discarded and lock released by the device")
+		jnpr.locked = false
+		jnpr.private = false
+
+		return jnpr.Close(sess.junosSleepSSHClosed)
+	}
+	var errs configClearError
+	if sess.junosConfigPrivate {
+		// closing the private candidate discards the uncommitted changes
+		errs.clearErr = jnpr.netconfConfigClose()
+		sleepShort(sess.junosSleepShort)
+		jnpr.log().Debug("close private configuration", "error", errs.clearErr)
+	} else {
+		errs.clearErr = jnpr.netconfConfigClear()
+		sleepShort(sess.junosSleepShort)
+		jnpr.log().Debug("clear candidate configuration", "error", errs.clearErr)
+		errs.unlockErr = jnpr.netconfConfigUnlock()
+		sleepShort(sess.junosSleepShort)
+		jnpr.log().Debug("unlock candidate configuration", "error", errs.unlockErr)
+	}
+	if errs.clearErr == nil && errs.unlockErr == nil {
+		return nil
+	}
+	errs.closeErr = jnpr.Close(sess.junosSleepSSHClosed)
+	jnpr.log().Error("failed to clear candidate configuration", "error", errs.Error())
+
+	return &errs
+}
+
 func sleep(timeSleep int) {
 	time.Sleep(time.Duration(timeSleep) * time.Second)
 }
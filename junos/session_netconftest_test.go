@@ -0,0 +1,1467 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"terraform-provider-junos/junos/internal/netconftest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func newTestSessionWithServer(t *testing.T) (*Session, *netconftest.Server) {
+	t.Helper()
+	server, err := netconftest.NewServer("test", "test")
+	if err != nil {
+		t.Fatalf("failed to start netconf server: %s", err)
+	}
+	t.Cleanup(func() { _ = server.Close() })
+	host, port, err := net.SplitHostPort(server.Addr)
+	if err != nil {
+		t.Fatalf("failed to split address of netconf server: %s", err)
+	}
+	portNum, _ := strconv.Atoi(port)
+
+	return &Session{
+		junosIP:              host,
+		junosPort:            portNum,
+		junosUserName:        "test",
+		junosPassword:        "test",
+		junosSleepLock:       1,
+		junosSSHFingerprints: []string{server.HostKeyFingerprint()},
+	}, server
+}
+
+func TestSessionNetconftestStaticRoute(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	if jnpr.SystemInformation.HardwareModel != "vsrx" {
+		t.Errorf("hardware model = %q, want %q", jnpr.SystemInformation.HardwareModel, "vsrx")
+	}
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{
+		"set routing-options static route 192.0.2.0/24 next-hop 198.51.100.1",
+		"set routing-options static route 192.0.2.0/24 preference 10",
+		"set routing-options static route 192.0.2.0/24 community 65000:1",
+	}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	if _, err := sess.commitConf("test", jnpr); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+	if server.Commits() != 1 {
+		t.Errorf("commits = %d, want 1", server.Commits())
+	}
+	route, err := readStaticRoute("192.0.2.0/24", defaultWord, sess, jnpr)
+	if err != nil {
+		t.Fatalf("readStaticRoute: %s", err)
+	}
+	want := staticRouteOptions{
+		preference:      10,
+		destination:     "192.0.2.0/24",
+		routingInstance: defaultWord,
+		community:       []string{"65000:1"},
+		nextHop:         []string{"198.51.100.1"},
+	}
+	if !reflect.DeepEqual(route, want) {
+		t.Errorf("readStaticRoute = %+v, want %+v", route, want)
+	}
+	route, err = readStaticRoute("203.0.113.0/24", defaultWord, sess, jnpr)
+	if err != nil {
+		t.Fatalf("readStaticRoute: %s", err)
+	}
+	if route.destination != "" {
+		t.Errorf("readStaticRoute on missing route = %+v, want empty", route)
+	}
+}
+
+func TestSessionNetconftestLockDenied(t *testing.T) {
+	sess, _ := newTestSessionWithServer(t)
+	sess.junosLockTimeout = 1
+	jnpr, err := sess.dialNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("dialNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	other, err := sess.dialNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("dialNewSession: %s", err)
+	}
+	defer sess.closeSession(other)
+	err = sess.configLock(context.Background(), other)
+	var lockErr *lockDeniedError
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("configLock on locked configuration = %v, want a *lockDeniedError", err)
+	}
+	if lockErr.sessionID == "" {
+		t.Errorf("lock holder session-id not found in %q", err)
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+	if err := sess.configLock(context.Background(), other); err != nil {
+		t.Fatalf("configLock after unlock: %s", err)
+	}
+	if err := sess.configClear(other); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+}
+
+func TestSessionNetconftestContextCanceled(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	server.RPCHook = func(method string) netconftest.HookAction {
+		if method == "load-configuration" {
+			close(entered)
+			<-release
+		}
+
+		return netconftest.Continue
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	jnpr, err := sess.startNewSession(ctx)
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	if err := sess.configLock(ctx, jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	go func() {
+		<-entered
+		cancel()
+	}()
+	err = sess.configSet([]string{"set system host-name canceled"}, jnpr)
+	close(release)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("configSet with canceled context = %v, want %v", err, context.Canceled)
+	}
+	if !jnpr.interrupted {
+		t.Errorf("session not marked interrupted after canceled rpc")
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Errorf("configClear of interrupted session: %s", err)
+	}
+	if _, err := sess.commitConf("canceled", jnpr); err == nil {
+		t.Errorf("commitConf on interrupted session: want an error")
+	}
+
+	// the end of the interrupted session released the lock and discarded its changes
+	sess.junosLockTimeout = 5
+	other, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(other)
+	if err := sess.configLock(context.Background(), other); err != nil {
+		t.Fatalf("configLock after interrupted session: %s", err)
+	}
+	if _, err := sess.commitConf("after canceled", other); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	if err := sess.configClear(other); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+	for _, line := range server.Running() {
+		if strings.Contains(line, "canceled") {
+			t.Errorf("changes of interrupted session committed: %q", line)
+		}
+	}
+
+	// rpc isn't sent with a context already done
+	ctxDone, cancelDone := context.WithCancel(context.Background())
+	cancelDone()
+	jnpr, err = sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	jnpr.ctx = ctxDone
+	if _, err := sess.command("show version", jnpr); !errors.Is(err, context.Canceled) {
+		t.Errorf("command with done context = %v, want %v", err, context.Canceled)
+	}
+	if jnpr.broken {
+		t.Errorf("session marked broken by a rpc not sent")
+	}
+	if _, err := sess.startNewSession(ctxDone); !errors.Is(err, context.Canceled) {
+		t.Errorf("startNewSession with done context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSessionNetconftestTransportRetry(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	sess.junosRetryCount = 2
+	sess.netconfPool = newNetconfPool(1)
+	t.Cleanup(func() { sess.netconfPool.close(0) })
+	sess.control = deviceControlFor(server.Addr, 10)
+	var dropMutex sync.Mutex
+	drops := make(map[string]netconftest.HookAction)
+	dropNext := func(method string, action netconftest.HookAction) {
+		dropMutex.Lock()
+		defer dropMutex.Unlock()
+		drops[method] = action
+	}
+	server.RPCHook = func(method string) netconftest.HookAction {
+		dropMutex.Lock()
+		defer dropMutex.Unlock()
+		action, ok := drops[method]
+		if !ok {
+			return netconftest.Continue
+		}
+		delete(drops, method)
+
+		return action
+	}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer func() { sess.closeSession(jnpr) }()
+
+	// read-only command replayed on a new session
+	dropNext("command", netconftest.DropBefore)
+	if _, err := sess.command("show configuration system | display set", jnpr); err != nil {
+		t.Fatalf("command with transport failure: %s", err)
+	}
+	if jnpr.broken {
+		t.Errorf("session still broken after reconnect")
+	}
+
+	// lock and lines loaded before the failure restored on the new session
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{"set system host-name retry1"}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	dropNext("load-configuration", netconftest.DropBefore)
+	if err := sess.configSet([]string{"set system domain-name example.com"}, jnpr); err != nil {
+		t.Fatalf("configSet with transport failure: %s", err)
+	}
+	if !jnpr.locked {
+		t.Errorf("candidate configuration not locked again after reconnect")
+	}
+	// commit not done before the failure, commit again
+	dropNext("commit-configuration", netconftest.DropBefore)
+	if _, err := sess.commitConf("retry commit not done", jnpr); err != nil {
+		t.Fatalf("commitConf with transport failure before commit: %s", err)
+	}
+	if server.Commits() != 1 {
+		t.Errorf("commits = %d, want 1", server.Commits())
+	}
+	wantRunning := []string{"set system host-name retry1", "set system domain-name example.com"}
+	if running := server.Running(); !reflect.DeepEqual(running, wantRunning) {
+		t.Errorf("running configuration = %v, want %v", running, wantRunning)
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+
+	// commit done before the failure, not committed again
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{"set system host-name retry2"}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	dropNext("commit-configuration", netconftest.DropAfter)
+	if _, err := sess.commitConf("retry commit done", jnpr); err != nil {
+		t.Fatalf("commitConf with transport failure after commit: %s", err)
+	}
+	if server.Commits() != 2 {
+		t.Errorf("commits = %d, want 2", server.Commits())
+	}
+	if jnpr.locked || jnpr.commitSlot != nil {
+		t.Errorf("candidate configuration locked or commit slot kept after a commit done before the failure")
+	}
+	// the commit slot of device is released with the lock and the session is put back in the pool without it
+	sess.closeSession(jnpr)
+	jnpr, err = sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	if jnpr.commitSlot != nil {
+		t.Errorf("session reused from the pool keeps the commit slot")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	other := &NetconfObject{}
+	if _, err := sess.acquireCommit(ctx, other); err != nil {
+		t.Errorf("commit slot not released after a commit done before the failure: %s", err)
+	}
+	other.releaseCommit()
+
+	// commit with the same log message as the last commit not done before the failure, commit again
+	// and 'show system commit' is only read after the failure
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{"set system host-name retry3"}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	var commands int
+	server.RPCHook = func(method string) netconftest.HookAction {
+		if method == "command" {
+			commands++
+		}
+
+		return netconftest.Continue
+	}
+	if _, err := sess.commitConf("retry commit done", jnpr); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	if commands != 0 {
+		t.Errorf("commands during a commit without transport failure = %d, want 0", commands)
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{"set system host-name retry4"}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	dropped := false
+	server.RPCHook = func(method string) netconftest.HookAction {
+		switch {
+		case method == "command":
+			commands++
+		case method == "commit-configuration" && !dropped:
+			dropped = true
+
+			return netconftest.DropBefore
+		}
+
+		return netconftest.Continue
+	}
+	if _, err := sess.commitConf("retry commit done", jnpr); err != nil {
+		t.Fatalf("commitConf with transport failure before commit: %s", err)
+	}
+	if server.Commits() != 4 {
+		t.Errorf("commits = %d, want 4", server.Commits())
+	}
+	if commands != 1 {
+		t.Errorf("commands during a commit with transport failure = %d, want 1", commands)
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+
+	// without retry, the transport failure is returned
+	sess.junosRetryCount = 0
+	server.RPCHook = func(method string) netconftest.HookAction {
+		if method == "command" {
+			return netconftest.DropBefore
+		}
+
+		return netconftest.Continue
+	}
+	if _, err := sess.command("show configuration system | display set", jnpr); err == nil {
+		t.Errorf("command with transport failure and without retry: want an error")
+	}
+}
+
+func TestSessionNetconftestBatchCommit(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	sess.commitBatcher = newCommitBatcher(1)
+	commitWithTwoResources := func() []error {
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				jnpr, err := sess.dialNewSession(context.Background())
+				if err != nil {
+					errs[i] = err
+
+					return
+				}
+				defer sess.closeSession(jnpr)
+				if err := sess.configLock(context.Background(), jnpr); err != nil {
+					errs[i] = err
+
+					return
+				}
+				if err := sess.configSet([]string{
+					"set routing-options static route 192.0.2." + strconv.Itoa(i) + "/32 discard",
+				}, jnpr); err != nil {
+					errs[i] = err
+
+					return
+				}
+				_, errs[i] = sess.commitConf("create resource "+strconv.Itoa(i), jnpr)
+			}(i)
+		}
+		wg.Wait()
+
+		return errs
+	}
+
+	server.CommitHook = func(config []string) error {
+		return errors.New("commit rejected")
+	}
+	for i, err := range commitWithTwoResources() {
+		if err == nil || !strings.Contains(err.Error(), "commit rejected") {
+			t.Errorf("resource %d: error = %v, want the error of the batch commit", i, err)
+		}
+	}
+	if server.Commits() != 0 {
+		t.Errorf("commits = %d after a failed batch, want 0", server.Commits())
+	}
+
+	server.CommitHook = nil
+	for i, err := range commitWithTwoResources() {
+		if err != nil {
+			t.Errorf("resource %d: unexpected error: %s", i, err)
+		}
+	}
+	if server.Commits() != 1 {
+		t.Errorf("commits = %d, want 1 for the batch", server.Commits())
+	}
+	if len(server.Running()) != 2 {
+		t.Errorf("running configuration = %q, want the lines of the 2 resources", server.Running())
+	}
+
+	// an error on the configuration of one resource is returned only to this resource
+	if err := server.SetRunning(nil); err != nil {
+		t.Fatalf("SetRunning: %s", err)
+	}
+	server.CommitHook = func(config []string) error {
+		for _, line := range config {
+			if strings.Contains(line, "192.0.2.0/32") {
+				return &netconftest.CommitError{
+					Path:    "[edit routing-options static route 192.0.2.0/32]",
+					Element: "discard",
+					Message: "route rejected",
+				}
+			}
+		}
+
+		return nil
+	}
+	errs := commitWithTwoResources()
+	if errs[0] == nil || !strings.Contains(errs[0].Error(), "route rejected") ||
+		!strings.Contains(errs[0].Error(), "failed on the configuration of create resource 0") {
+		t.Errorf("resource 0: error = %v, want the error on its configuration", errs[0])
+	}
+	if errs[1] != nil {
+		t.Errorf("resource 1: error = %v, want committed without resource 0", errs[1])
+	}
+	want := []string{"set routing-options static route 192.0.2.1/32 discard"}
+	if !reflect.DeepEqual(server.Running(), want) {
+		t.Errorf("running configuration = %q, want %q", server.Running(), want)
+	}
+	server.CommitHook = nil
+
+	// a batch waiting for a session still collecting changes is committed by a flush
+	sess.commitBatcher = newCommitBatcher(60)
+	collecting, err := sess.dialNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("dialNewSession: %s", err)
+	}
+	defer sess.closeSession(collecting)
+	if err := sess.configLock(context.Background(), collecting); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	commitsBefore := server.Commits()
+	done := make(chan error, 1)
+	go func() {
+		jnpr, err := sess.dialNewSession(context.Background())
+		if err != nil {
+			done <- err
+
+			return
+		}
+		defer sess.closeSession(jnpr)
+		if err := sess.configLock(context.Background(), jnpr); err != nil {
+			done <- err
+
+			return
+		}
+		if err := sess.configSet([]string{"set routing-options static route 192.0.2.2/32 discard"}, jnpr); err != nil {
+			done <- err
+
+			return
+		}
+		_, err = sess.commitConf("create resource 2", jnpr)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("commit of batch before the flush (error %v), want a wait of the collecting session", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+	sess.commitBatcher.flush(sess)
+	if err := <-done; err != nil {
+		t.Errorf("resource 2: unexpected error: %s", err)
+	}
+	if server.Commits() != commitsBefore+1 {
+		t.Errorf("commits = %d, want %d after the flush", server.Commits(), commitsBefore+1)
+	}
+}
+
+func TestSessionNetconftestLogicalSystem(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	sess.junosLogicalSystem = "LS1"
+	jnpr, err := sess.dialNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("dialNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{
+		"set routing-options static route 192.0.2.0/24 discard",
+	}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	if _, err := sess.commitConf("test", jnpr); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+	wantRunning := []string{"set logical-systems LS1 routing-options static route 192.0.2.0/24 discard"}
+	if !reflect.DeepEqual(server.Running(), wantRunning) {
+		t.Errorf("running configuration = %q, want %q", server.Running(), wantRunning)
+	}
+	route, err := readStaticRoute("192.0.2.0/24", defaultWord, sess, jnpr)
+	if err != nil {
+		t.Fatalf("readStaticRoute: %s", err)
+	}
+	if route.destination != "192.0.2.0/24" || !route.discard {
+		t.Errorf("readStaticRoute in logical system = %+v, want the route with discard", route)
+	}
+	read, err := sess.command("show configuration routing-options | display set", jnpr)
+	if err != nil {
+		t.Fatalf("command: %s", err)
+	}
+	if !strings.Contains(read, "set routing-options static route 192.0.2.0/24 discard") {
+		t.Errorf("output of display set %q doesn't contain the line without logical system", read)
+	}
+}
+
+func TestSessionNetconftestLogicalSystemImport(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	server.SetRunning([]string{
+		"set routing-options static route 192.0.2.0/24 discard",
+		"set logical-systems LS1 routing-options static route 198.51.100.0/24 discard",
+		"set tenants TN1 routing-options static route 203.0.113.0/24 discard",
+	})
+	res := resourcesWithLogicalSystem(map[string]*schema.Resource{
+		"junos_static_route": resourceStaticRoute(),
+	})["junos_static_route"]
+
+	tests := map[string]struct {
+		sessLogicalSystem string
+		id                string
+		wantDestination   string
+		wantID            string
+	}{
+		"root": {
+			id:              "192.0.2.0/24" + idSeparator + defaultWord,
+			wantDestination: "192.0.2.0/24",
+			wantID:          "192.0.2.0/24" + idSeparator + defaultWord,
+		},
+		"logical_system_in_id": {
+			id:              "logical-systems:LS1" + logicalSystemSeparator + "198.51.100.0/24" + idSeparator + defaultWord,
+			wantDestination: "198.51.100.0/24",
+			wantID:          "logical-systems:LS1" + logicalSystemSeparator + "198.51.100.0/24" + idSeparator + defaultWord,
+		},
+		"tenant_in_id": {
+			id:              "tenants:TN1" + logicalSystemSeparator + "203.0.113.0/24" + idSeparator + defaultWord,
+			wantDestination: "203.0.113.0/24",
+			wantID:          "tenants:TN1" + logicalSystemSeparator + "203.0.113.0/24" + idSeparator + defaultWord,
+		},
+		"logical_system_of_provider": {
+			sessLogicalSystem: "LS1",
+			id:                "198.51.100.0/24" + idSeparator + defaultWord,
+			wantDestination:   "198.51.100.0/24",
+			wantID:            "logical-systems:LS1" + logicalSystemSeparator + "198.51.100.0/24" + idSeparator + defaultWord,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			sessCopy := *sess
+			sessCopy.junosLogicalSystem = tt.sessLogicalSystem
+			d := res.Data(nil)
+			d.SetId(tt.id)
+			result, err := res.Importer.StateContext(context.Background(), d, &sessCopy)
+			if err != nil {
+				t.Fatalf("import: %s", err)
+			}
+			if result[0].Id() != tt.wantID || result[0].Get("destination").(string) != tt.wantDestination {
+				t.Errorf("imported id %q and destination %q, want %q and %q",
+					result[0].Id(), result[0].Get("destination"), tt.wantID, tt.wantDestination)
+			}
+			// read with the id in state stays under the logical system or tenant of id
+			if diags := res.ReadContext(context.Background(), result[0], sess); diags.HasError() {
+				t.Fatalf("read: %v", diags)
+			}
+			if result[0].Id() != tt.wantID {
+				t.Errorf("id after read = %q, want %q", result[0].Id(), tt.wantID)
+			}
+		})
+	}
+
+	for _, id := range []string{
+		"logical-systems:LS2" + logicalSystemSeparator + "198.51.100.0/24" + idSeparator + defaultWord,
+		"systems:LS1" + logicalSystemSeparator + "198.51.100.0/24" + idSeparator + defaultWord,
+		"logical-systems:" + logicalSystemSeparator + "198.51.100.0/24" + idSeparator + defaultWord,
+	} {
+		d := res.Data(nil)
+		d.SetId(id)
+		if _, err := res.Importer.StateContext(context.Background(), d, sess); err == nil {
+			t.Errorf("import with id %q: want an error", id)
+		}
+	}
+}
+
+func TestSessionNetconftestDiff(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	sess.junosDiffPlan = true
+	sess.junosDiffAuditFile = filepath.Join(t.TempDir(), "diff.log")
+	if err := server.SetRunning([]string{
+		"set routing-options static route 192.0.2.0/24 discard",
+	}); err != nil {
+		t.Fatalf("SetRunning: %s", err)
+	}
+	lines := []string{
+		"delete routing-options static route 192.0.2.0/24",
+		"set routing-options static route 192.0.2.0/24 next-hop 198.51.100.1",
+	}
+	compare, _, err := sess.planCheck(context.Background(), func(jnpr *NetconfObject) error {
+		return sess.configSet(lines, jnpr)
+	})
+	if err != nil {
+		t.Fatalf("planCheck: %s", err)
+	}
+	wantCompare := "[edit]\n" +
+		"- set routing-options static route 192.0.2.0/24 discard\n" +
+		"+ set routing-options static route 192.0.2.0/24 next-hop 198.51.100.1"
+	if compare != wantCompare {
+		t.Errorf("planCheck compare = %q, want %q", compare, wantCompare)
+	}
+	if server.Commits() != 0 {
+		t.Errorf("commits after planCheck = %d, want 0", server.Commits())
+	}
+
+	jnpr, err := sess.dialNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("dialNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet(lines, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	warns, err := sess.commitConf("update route", jnpr)
+	if err != nil || len(warns) > 0 {
+		t.Fatalf("commitConf: %v %v", warns, err)
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+	audit, err := ioutil.ReadFile(sess.junosDiffAuditFile)
+	if err != nil {
+		t.Fatalf("failed to read diff audit file: %s", err)
+	}
+	if !strings.Contains(string(audit), " : update route\n"+wantCompare+"\n") {
+		t.Errorf("diff audit file %q doesn't contain the log message and the diff", audit)
+	}
+}
+
+func TestDataSourceCommandNetconftest(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	server.Interfaces = []string{"ge-0/0/0"}
+	d := dataSourceCommand().Data(nil)
+	if err := d.Set("command", "show interfaces terse"); err != nil {
+		t.Fatal(err)
+	}
+	if diags := dataSourceCommandRead(context.Background(), d, sess); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if !strings.Contains(d.Get("output").(string), "ge-0/0/0") {
+		t.Errorf("output = %q, want the interface", d.Get("output"))
+	}
+	if !strings.Contains(d.Get("output_json").(string), `"output":`) {
+		t.Errorf("output_json = %q, want the output element", d.Get("output_json"))
+	}
+
+	d = dataSourceCommand().Data(nil)
+	if err := d.Set("command", "request system reboot"); err != nil {
+		t.Fatal(err)
+	}
+	if diags := dataSourceCommandRead(context.Background(), d, sess); !diags.HasError() {
+		t.Errorf("read with request command: want an error without allow_changes")
+	}
+}
+
+func TestGenerateConfigNetconftest(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	if err := server.SetRunning([]string{
+		"set routing-options static route 192.0.2.0/24 next-hop 198.51.100.1",
+		"set routing-options static route 192.0.2.0/24 preference 10",
+	}); err != nil {
+		t.Fatalf("SetRunning: %s", err)
+	}
+	var output strings.Builder
+	err := generateConfig(context.Background(), sess, Provider().ResourcesMap, []string{"junos_static_route"}, &output)
+	if err != nil {
+		t.Fatalf("generateConfig: %s", err)
+	}
+	want := `import {
+  to = junos_static_route._192_0_2_0_24_-_default
+  id = "192.0.2.0/24_-_default"
+}
+
+resource "junos_static_route" "_192_0_2_0_24_-_default" {
+  destination = "192.0.2.0/24"
+  next_hop    = ["198.51.100.1"]
+  preference  = 10
+}
+
+`
+	if output.String() != want {
+		t.Errorf("generateConfig output:\n%s\nwant:\n%s", output.String(), want)
+	}
+}
+
+func TestSessionNetconftestConfigSnapshot(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	sess.configSnapshot = newConfigSnapshot(3600)
+	var rpcMutex sync.Mutex
+	rpcs := make(map[string]int)
+	server.RPCHook = func(method string) netconftest.HookAction {
+		rpcMutex.Lock()
+		defer rpcMutex.Unlock()
+		rpcs[method]++
+
+		return netconftest.Continue
+	}
+	rpcCount := func(method string) int {
+		rpcMutex.Lock()
+		defer rpcMutex.Unlock()
+
+		return rpcs[method]
+	}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	commit := func(lines ...string) {
+		t.Helper()
+		if err := sess.configLock(context.Background(), jnpr); err != nil {
+			t.Fatalf("configLock: %s", err)
+		}
+		if err := sess.configSet(lines, jnpr); err != nil {
+			t.Fatalf("configSet: %s", err)
+		}
+		if _, err := sess.commitConf("test", jnpr); err != nil {
+			t.Fatalf("commitConf: %s", err)
+		}
+		if err := sess.configClear(jnpr); err != nil {
+			t.Fatalf("configClear: %s", err)
+		}
+	}
+	commit(
+		"set system host-name \"fw 1\"",
+		"set routing-options static route 192.0.2.0/24 next-hop 198.51.100.1",
+		"set routing-options static route 192.0.2.0/24 preference 10",
+		"set routing-options static route 198.51.100.0/24 discard",
+	)
+
+	// the snapshot answers like the device
+	commands := []string{
+		"show configuration routing-options static route 192.0.2.0/24 | display set relative",
+		"show configuration routing-options static | display set",
+		"show configuration routing-options static route 198.51.100.0/24 | display set relative",
+		"show configuration routing-options static route 203.0.113.0/24 | display set relative",
+		"show configuration system host-name | display set",
+	}
+	for _, cmd := range commands {
+		want, err := jnpr.netconfCommand(cmd)
+		if err != nil && want != emptyWord {
+			t.Fatalf("netconfCommand(%q): %s", cmd, err)
+		}
+		got, err := sess.command(cmd, jnpr)
+		if err != nil {
+			t.Fatalf("command(%q): %s", cmd, err)
+		}
+		if got != want {
+			t.Errorf("command(%q) with snapshot = %q, want %q", cmd, got, want)
+		}
+	}
+	commandsBefore := rpcCount("command")
+	for _, cmd := range commands {
+		if _, err := sess.command(cmd, jnpr); err != nil {
+			t.Fatalf("command(%q): %s", cmd, err)
+		}
+	}
+	if _, err := readStaticRoute("192.0.2.0/24", defaultWord, sess, jnpr); err != nil {
+		t.Fatalf("readStaticRoute: %s", err)
+	}
+	route, err := readStaticRoute("192.0.2.0/24", defaultWord, sess, jnpr)
+	if err != nil {
+		t.Fatalf("readStaticRoute: %s", err)
+	}
+	if route.preference != 10 {
+		t.Errorf("readStaticRoute with snapshot = %+v, want preference 10", route)
+	}
+	if got := rpcCount("command") - commandsBefore; got != 0 {
+		t.Errorf("commands sent with a valid snapshot = %d, want 0", got)
+	}
+	if got := rpcCount("get-configuration"); got != 1 {
+		t.Errorf("get-configuration sent = %d, want 1", got)
+	}
+
+	// a commit invalidates the snapshot
+	commit("set routing-options static route 192.0.2.0/24 preference 20")
+	route, err = readStaticRoute("192.0.2.0/24", defaultWord, sess, jnpr)
+	if err != nil {
+		t.Fatalf("readStaticRoute: %s", err)
+	}
+	if route.preference != 20 {
+		t.Errorf("readStaticRoute after commit = %+v, want preference 20", route)
+	}
+	if got := rpcCount("get-configuration"); got != 2 {
+		t.Errorf("get-configuration sent after commit = %d, want 2", got)
+	}
+
+	// a session with changes in progress doesn't use the snapshot
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	commandsBefore = rpcCount("command")
+	if _, err := sess.command(commands[0], jnpr); err != nil {
+		t.Fatalf("command: %s", err)
+	}
+	if got := rpcCount("command") - commandsBefore; got != 1 {
+		t.Errorf("commands sent with lock = %d, want 1", got)
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+
+	// an expired snapshot is read again
+	sess.configSnapshot.ttl = 0
+	commandsBefore = rpcCount("command")
+	if _, err := sess.command(commands[0], jnpr); err != nil {
+		t.Fatalf("command: %s", err)
+	}
+	if got := rpcCount("command") - commandsBefore; got != 1 {
+		t.Errorf("commands sent with expired snapshot = %d, want 1", got)
+	}
+}
+
+// maxParallelCommands returns the maximum of commands in progress on server during parallel reads of sessions.
+func maxParallelCommands(t *testing.T, sess *Session, server *netconftest.Server, sessions int) int {
+	t.Helper()
+	var mutex sync.Mutex
+	current, maxCurrent := 0, 0
+	server.RPCHook = func(method string) netconftest.HookAction {
+		if method != "command" {
+			return netconftest.Continue
+		}
+		mutex.Lock()
+		current++
+		if current > maxCurrent {
+			maxCurrent = current
+		}
+		mutex.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		mutex.Lock()
+		current--
+		mutex.Unlock()
+
+		return netconftest.Continue
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, sessions)
+	for i := 0; i < sessions; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			jnpr, err := sess.startNewSession(context.Background())
+			if err != nil {
+				errs <- err
+
+				return
+			}
+			defer sess.closeSession(jnpr)
+			if _, err := sess.command("show configuration system | display set", jnpr); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("parallel read: %s", err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return maxCurrent
+}
+
+func TestSessionNetconftestReadParallelism(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	sess.control = deviceControlFor(server.Addr, 2)
+	if got := maxParallelCommands(t, sess, server, 5); got != 2 {
+		t.Errorf("parallel commands with read_parallelism 2 = %d, want 2", got)
+	}
+
+	// limited by max-sessions-per-connection of device
+	sess, server = newTestSessionWithServer(t)
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{"set system services ssh max-sessions-per-connection 1"}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	if _, err := sess.commitConf("test", jnpr); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	sess.closeSession(jnpr)
+	// read of max-sessions-per-connection tried again after a failure
+	control := deviceControlFor(server.Addr, 5)
+	dropped := false
+	server.RPCHook = func(method string) netconftest.HookAction {
+		if method == "command" && !dropped {
+			dropped = true
+
+			return netconftest.DropBefore
+		}
+
+		return netconftest.Continue
+	}
+	for _, wantLimit := range []int{5, 1} {
+		jnpr, err := sess.startNewSession(context.Background())
+		if err != nil {
+			t.Fatalf("startNewSession: %s", err)
+		}
+		control.limitReadParallelism(jnpr)
+		sess.closeSession(jnpr)
+		if control.readLimit != wantLimit {
+			t.Errorf("read limit = %d, want %d", control.readLimit, wantLimit)
+		}
+	}
+	if !control.limited {
+		t.Errorf("read parallelism not limited after a successful read of max-sessions-per-connection")
+	}
+	sess.control = control
+	if got := maxParallelCommands(t, sess, server, 3); got != 1 {
+		t.Errorf("parallel commands with max-sessions-per-connection 1 = %d, want 1", got)
+	}
+
+	// the smallest read_parallelism of providers connected on the device
+	sess, server = newTestSessionWithServer(t)
+	control = deviceControlFor(server.Addr, 4)
+	if deviceControlFor(server.Addr, 2) != control || deviceControlFor(server.Addr, 3) != control {
+		t.Errorf("several concurrency controls for the same device")
+	}
+	sess.control = control
+	if got := maxParallelCommands(t, sess, server, 5); got != 2 {
+		t.Errorf("parallel commands with read_parallelism 4, 2 and 3 = %d, want 2", got)
+	}
+}
+
+func TestSessionNetconftestCommitSlot(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	sess.control = deviceControlFor(server.Addr, 10)
+	var rpcMutex sync.Mutex
+	locks := 0
+	server.RPCHook = func(method string) netconftest.HookAction {
+		if method == "lock" {
+			rpcMutex.Lock()
+			locks++
+			rpcMutex.Unlock()
+		}
+
+		return netconftest.Continue
+	}
+	lockCount := func() int {
+		rpcMutex.Lock()
+		defer rpcMutex.Unlock()
+
+		return locks
+	}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	other, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(other)
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+
+	// the end of context stops the wait of the commit slot
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := sess.configLock(ctx, other); err == nil ||
+		!strings.Contains(err.Error(), "failed to wait the end of the commit of another resource") {
+		t.Errorf("configLock with commit slot taken = %v, want a wait error", err)
+	}
+
+	// the lock is tried on the device only after the unlock of the other session
+	locked := make(chan error)
+	go func() {
+		locked <- sess.configLock(context.Background(), other)
+	}()
+	select {
+	case err := <-locked:
+		t.Fatalf("configLock with commit slot taken returned %v before unlock", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+	if err := <-locked; err != nil {
+		t.Fatalf("configLock after unlock: %s", err)
+	}
+	if got := lockCount(); got != 2 {
+		t.Errorf("lock rpcs = %d, want 2 (no lock denied by device)", got)
+	}
+	if err := sess.configClear(other); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+	if len(sess.control.commitSlot) != 0 {
+		t.Errorf("commit slot not released after configClear")
+	}
+}
+
+func TestSessionNetconftestAdoptExisting(t *testing.T) {
+	sess, _ := newTestSessionWithServer(t)
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{
+		"set routing-options static route 192.0.2.0/24 next-hop 198.51.100.1",
+		"set routing-options static route 192.0.2.0/24 preference 5",
+	}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	if _, err := sess.commitConf("hand edit", jnpr); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+	res := Provider().ResourcesMap["junos_static_route"]
+	raw := map[string]interface{}{
+		"destination": "192.0.2.0/24",
+		"next_hop":    []interface{}{"198.51.100.2"},
+	}
+
+	diags := res.CreateContext(context.Background(), schema.TestResourceDataRaw(t, res.Schema, raw), sess)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "already exists") {
+		t.Fatalf("create on existing route without adopt_existing = %v, want an already exists error", diags)
+	}
+
+	raw["adopt_existing"] = true
+	d := schema.TestResourceDataRaw(t, res.Schema, raw)
+	diags = res.CreateContext(context.Background(), d, sess)
+	if diags.HasError() {
+		t.Fatalf("create with adopt_existing: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning ||
+		!strings.Contains(diags[0].Summary, "junos_static_route") ||
+		!strings.Contains(diags[0].Detail, "static route 192.0.2.0/24 already exists") ||
+		!strings.Contains(diags[0].Detail, "replaced or removed : next_hop, preference") {
+		t.Errorf("create with adopt_existing diagnostics = %v, want an adopted warning "+
+			"with the attributes not kept", diags)
+	}
+	if d.Id() == "" {
+		t.Errorf("adopted route not recorded in state")
+	}
+	route, err := readStaticRoute("192.0.2.0/24", defaultWord, sess, jnpr)
+	if err != nil {
+		t.Fatalf("readStaticRoute: %s", err)
+	}
+	want := staticRouteOptions{
+		destination:     "192.0.2.0/24",
+		routingInstance: defaultWord,
+		nextHop:         []string{"198.51.100.2"},
+	}
+	if !reflect.DeepEqual(route, want) {
+		t.Errorf("adopted route = %+v, want %+v", route, want)
+	}
+
+	diags = res.CreateContext(context.Background(), schema.TestResourceDataRaw(t, res.Schema, raw), sess)
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "All attributes of the existing configuration") {
+		t.Errorf("create with adopt_existing on the same route = %v, want a warning with attributes kept", diags)
+	}
+}
+
+func TestAdoptReadExistingSetFailed(t *testing.T) {
+	res := &schema.Resource{Schema: map[string]*schema.Schema{
+		"name": {Type: schema.TypeInt, Optional: true},
+	}}
+	planned := &schema.Resource{Schema: map[string]*schema.Schema{
+		"name": {Type: schema.TypeString, Optional: true},
+	}}
+	d := schema.TestResourceDataRaw(t, planned.Schema, map[string]interface{}{"name": "not_int"})
+	read := func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		t.Errorf("read of existing configuration called with an attribute not set")
+
+		return nil
+	}
+	if existing := adoptReadExisting(context.Background(), "junos_test", res, read, d, &Session{}); existing != nil {
+		t.Errorf("adoptReadExisting with an attribute not set = %v, want nil", existing)
+	}
+}
+
+func TestSessionNetconftestConfigGroup(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	sess.junosConfigGroup = "terraform"
+	if err := server.SetRunning([]string{
+		"set routing-options static route 198.51.100.0/24 discard",
+	}); err != nil {
+		t.Fatalf("SetRunning: %s", err)
+	}
+	jnpr, err := sess.dialNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("dialNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	if err := sess.configLock(context.Background(), jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	for _, line := range []string{
+		"set routing-options static route 192.0.2.0/24 discard",
+		"set routing-options static route 203.0.113.0/24 reject",
+	} {
+		if err := sess.configSet([]string{line}, jnpr); err != nil {
+			t.Fatalf("configSet: %s", err)
+		}
+	}
+	applyGroups := 0
+	for _, line := range jnpr.loadedLines {
+		if line == "set apply-groups terraform" {
+			applyGroups++
+		}
+	}
+	if applyGroups != 1 {
+		t.Errorf("loaded lines %q contain %d apply-groups, want 1", jnpr.loadedLines, applyGroups)
+	}
+	if _, err := sess.commitConf("test", jnpr); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	if err := sess.configClear(jnpr); err != nil {
+		t.Fatalf("configClear: %s", err)
+	}
+	running := strings.Join(server.Running(), "\n")
+	for _, line := range []string{
+		"set routing-options static route 198.51.100.0/24 discard",
+		"set groups terraform routing-options static route 192.0.2.0/24 discard",
+		"set groups terraform routing-options static route 203.0.113.0/24 reject",
+		"set apply-groups terraform",
+	} {
+		if !strings.Contains(running, line) {
+			t.Errorf("running configuration %q doesn't contain %q", running, line)
+		}
+	}
+	if strings.Contains(running, "set routing-options static route 192.0.2.0/24") {
+		t.Errorf("running configuration %q contains the route outside the group", running)
+	}
+
+	route, err := readStaticRoute("192.0.2.0/24", defaultWord, sess, jnpr)
+	if err != nil {
+		t.Fatalf("readStaticRoute: %s", err)
+	}
+	if route.destination != "192.0.2.0/24" || !route.discard {
+		t.Errorf("readStaticRoute in group = %+v, want the route with discard", route)
+	}
+	route, err = readStaticRoute("198.51.100.0/24", defaultWord, sess, jnpr)
+	if err != nil {
+		t.Fatalf("readStaticRoute: %s", err)
+	}
+	if route.destination != "" {
+		t.Errorf("readStaticRoute outside group = %+v, want not found", route)
+	}
+
+	managed := []interface{}{
+		map[string]interface{}{
+			"resource_type": "junos_static_route",
+			"ids": schema.NewSet(schema.HashString, []interface{}{
+				"srx1" + deviceSeparator + "192.0.2.0/24" + idSeparator + defaultWord,
+			}),
+		},
+		// the same id with another resource type doesn't manage the route
+		map[string]interface{}{
+			"resource_type": "junos_rib_group",
+			"ids": schema.NewSet(schema.HashString, []interface{}{
+				"203.0.113.0/24" + idSeparator + defaultWord,
+			}),
+		},
+	}
+	orphans, err := readConfigGroupOrphans(managed, []string{"junos_static_route"}, sess, jnpr)
+	if err != nil {
+		t.Fatalf("readConfigGroupOrphans: %s", err)
+	}
+	wantOrphans := []map[string]interface{}{
+		{"resource_type": "junos_static_route", "id": "203.0.113.0/24" + idSeparator + defaultWord},
+	}
+	if !reflect.DeepEqual(orphans, wantOrphans) {
+		t.Errorf("orphans of group = %v, want %v", orphans, wantOrphans)
+	}
+}
+
+func TestSessionNetconftestPool(t *testing.T) {
+	sess, _ := newTestSessionWithServer(t)
+	sess.netconfPool = newNetconfPool(1)
+	t.Cleanup(func() { sess.netconfPool.close(0) })
+
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	// the only slot is taken, a second session waits the end of ctx
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := sess.startNewSession(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("startNewSession on full pool = %v, want a deadline exceeded error", err)
+	}
+	sess.closeSession(jnpr)
+
+	reused, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	if reused != jnpr {
+		t.Errorf("startNewSession after closeSession didn't reuse the idle session")
+	}
+	reused.broken = true
+	sess.closeSession(reused)
+	if len(sess.netconfPool.idle) != 0 {
+		t.Errorf("broken session kept in pool")
+	}
+
+	fresh, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	if fresh == jnpr {
+		t.Errorf("startNewSession returned the broken session")
+	}
+	sess.closeSession(fresh)
+	// a dead idle session (closed by the device) is dropped and replaced on take
+	_ = fresh.Session.Close()
+	replaced, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession after dead idle session: %s", err)
+	}
+	if replaced == fresh {
+		t.Errorf("startNewSession returned the dead idle session")
+	}
+	sess.closeSession(replaced)
+
+	// a session keeping the commit slot of device is closed to release it
+	sess.netconfPool.slots <- struct{}{}
+	if sess.netconfPool.put(&NetconfObject{commitSlot: make(chan struct{}, 1)}) {
+		t.Errorf("session with the commit slot kept in pool")
+	}
+}
+
+func TestSessionNetconftestCommitConfirmed(t *testing.T) {
+	for name, c := range map[string]struct {
+		healthCheck string
+		wantErr     string
+	}{
+		"no_health_check":      {},
+		"health_check_command": {healthCheck: "show system commit"},
+		"health_check_rpc":     {healthCheck: "<command>show system commit</command>"},
+		"health_check_failed": {
+			healthCheck: "show bgp summary",
+			wantErr:     "health check failed after commit confirmed, configuration will be rolled back in 2 minute(s)",
+		},
+	} {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			sess, server := newTestSessionWithServer(t)
+			sess.junosCommitConfirmed = 2
+			sess.junosCommitConfirmedCheck = c.healthCheck
+			if err := server.SetRunning([]string{"set routing-options static route 198.51.100.0/24 discard"}); err != nil {
+				t.Fatalf("SetRunning: %s", err)
+			}
+			var connects int
+			var connectsMutex sync.Mutex
+			server.RPCHook = func(method string) netconftest.HookAction {
+				if method == "get-system-information" {
+					connectsMutex.Lock()
+					connects++
+					connectsMutex.Unlock()
+				}
+
+				return netconftest.Continue
+			}
+			jnpr, err := sess.startNewSession(context.Background())
+			if err != nil {
+				t.Fatalf("startNewSession: %s", err)
+			}
+			defer sess.closeSession(jnpr)
+			if err := sess.configLock(context.Background(), jnpr); err != nil {
+				t.Fatalf("configLock: %s", err)
+			}
+			if err := sess.configSet([]string{"set routing-options static route 192.0.2.0/24 discard"}, jnpr); err != nil {
+				t.Fatalf("configSet: %s", err)
+			}
+			_, err = sess.commitConf("commit confirmed", jnpr)
+			connectsMutex.Lock()
+			if connects != 2 {
+				t.Errorf("sessions opened = %d, want 2 (action and reconnect check)", connects)
+			}
+			connectsMutex.Unlock()
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("commitConf() = %v, want error containing %q", err, c.wantErr)
+				}
+				if server.Commits() != 1 || !server.CommitConfirmedPending() {
+					t.Errorf("commits = %d (pending %t), want only the commit confirmed",
+						server.Commits(), server.CommitConfirmedPending())
+				}
+				// the device rolls back at the end of the confirm timeout
+				server.ExpireCommitConfirmed()
+				want := []string{"set routing-options static route 198.51.100.0/24 discard"}
+				if !reflect.DeepEqual(server.Running(), want) {
+					t.Errorf("running configuration after rollback = %q, want %q", server.Running(), want)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("commitConf: %s", err)
+			}
+			if server.Commits() != 2 || server.CommitConfirmedPending() {
+				t.Errorf("commits = %d (pending %t), want the commit confirmed and its confirmation",
+					server.Commits(), server.CommitConfirmedPending())
+			}
+			// a confirmed commit is not rolled back
+			server.ExpireCommitConfirmed()
+			if !strings.Contains(strings.Join(server.Running(), "\n"), "static route 192.0.2.0/24 discard") {
+				t.Errorf("running configuration %q doesn't contain the confirmed route", server.Running())
+			}
+		})
+	}
+}
+
+func TestCommitCheckOnPlanNetconftest(t *testing.T) {
+	sess, server := newTestSessionWithServer(t)
+	sess.junosCommitCheckPlan = true
+	res := Provider().ResourcesMap["junos_static_route"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"destination": "192.0.2.0/24",
+		"next_hop":    []interface{}{"198.51.100.1"},
+	})
+
+	server.CommitHook = func(config []string) error {
+		return &netconftest.CommitError{
+			Path:    "[edit routing-options static route 192.0.2.0/24]",
+			Element: "next-hop",
+			Message: "next-hop is not reachable",
+		}
+	}
+	_, err := res.SimpleDiff(context.Background(), nil, config, sess)
+	// not wrapped to be converted by the SDK in a diagnostic with the attribute
+	pathErr, ok := err.(cty.PathError) // nolint: errorlint
+	if !ok {
+		t.Fatalf("plan with commit check error = %v, want an error attached to an attribute", err)
+	}
+	if !pathErr.Path.Equals(cty.GetAttrPath("next_hop")) ||
+		!strings.Contains(err.Error(), "commit check of junos_static_route failed") ||
+		!strings.Contains(err.Error(), "next-hop is not reachable") {
+		t.Errorf("plan with commit check error = %q on %#v, want the error on next_hop", err, pathErr.Path)
+	}
+
+	server.CommitHook = func(config []string) error {
+		return &netconftest.CommitError{
+			Warning: true,
+			Path:    "[edit routing-options static route 192.0.2.0/24]",
+			Element: "next-hop",
+			Message: "next-hop overlaps an interface route",
+		}
+	}
+	collector := &planWarnings{}
+	ctx := context.WithValue(context.Background(), planWarningsKey{}, collector)
+	if _, err := res.SimpleDiff(ctx, nil, config, sess); err != nil {
+		t.Fatalf("plan with commit check warning: %s", err)
+	}
+	if len(collector.warnings) != 1 ||
+		collector.warnings[0].Severity != tfprotov5.DiagnosticSeverityWarning ||
+		!strings.Contains(collector.warnings[0].Summary, "junos_static_route") ||
+		!strings.Contains(collector.warnings[0].Detail, "next-hop overlaps an interface route") {
+		t.Errorf("plan warnings = %v, want the warning of commit check", collector.warnings)
+	}
+
+	server.CommitHook = func(config []string) error {
+		return netconftest.CommitErrors{
+			{
+				Warning: true,
+				Path:    "[edit routing-options static route 192.0.2.0/24]",
+				Element: "next-hop",
+				Message: "next-hop overlaps an interface route",
+			},
+			{
+				Path:    "[edit routing-options static route 192.0.2.0/24]",
+				Element: "next-hop",
+				Message: "next-hop is not reachable",
+			},
+		}
+	}
+	_, err = res.SimpleDiff(context.Background(), nil, config, sess)
+	if err == nil || !strings.Contains(err.Error(), "next-hop is not reachable") ||
+		!strings.Contains(err.Error(), "Warnings: next-hop overlaps an interface route") {
+		t.Errorf("plan with commit check warning and error = %v, want the error with the warning", err)
+	}
+	if server.Commits() != 0 {
+		t.Errorf("commits after plan = %d, want 0", server.Commits())
+	}
+}
@@ -0,0 +1,228 @@
+package junos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+const (
+	fakeReplyOk       = "<rpc-reply><ok/></rpc-reply>"
+	fakeReplyRPCError = "<rpc-reply><rpc-error><error-severity>error</error-severity>" +
+		"<error-message>rpc failed</error-message></rpc-error></rpc-reply>"
+)
+
+// fakeTransport : netconf transport with a reply (or a transport error) by rpc.
+type fakeTransport struct {
+	closed      bool
+	last        string
+	replies     map[string]string
+	sendErrors  map[string]bool
+	sentRPC     []string
+	knownRPCs   []string
+	receiveNext []byte
+}
+
+func newFakeTransport(replies map[string]string, sendErrors map[string]bool) *fakeTransport {
+	return &fakeTransport{
+		replies:    replies,
+		sendErrors: sendErrors,
+		knownRPCs: []string{
+			"<delete-config>", "<unlock>", "<lock>", "<close-configuration/>", "<close-session/>",
+		},
+	}
+}
+
+func (t *fakeTransport) Send(data []byte) error {
+	t.last = ""
+	for _, rpc := range t.knownRPCs {
+		if bytes.Contains(data, []byte(rpc)) {
+			t.last = rpc
+		}
+	}
+	t.sentRPC = append(t.sentRPC, t.last)
+	if t.closed || t.sendErrors[t.last] {
+		return errors.New("broken pipe")
+	}
+	if reply, ok := t.replies[t.last]; ok {
+		t.receiveNext = []byte(reply)
+	} else {
+		t.receiveNext = []byte(fakeReplyOk)
+	}
+
+	return nil
+}
+
+func (t *fakeTransport) Receive() ([]byte, error) {
+	return t.receiveNext, nil
+}
+
+func (t *fakeTransport) Close() error {
+	t.closed = true
+
+	return nil
+}
+
+func (t *fakeTransport) ReceiveHello() (*netconf.HelloMessageReceive, error) {
+	return &netconf.HelloMessageReceive{}, nil
+}
+
+func (t *fakeTransport) SendHello(*netconf.HelloMessageSend) error {
+	return nil
+}
+
+func TestConfigClear(t *testing.T) {
+	tests := map[string]struct {
+		private    bool
+		replies    map[string]string
+		sendErrors map[string]bool
+		wantRPC    []string
+		wantErrs   []string
+		wantClosed bool
+	}{
+		"clear_unlock_ok": {
+			wantRPC: []string{"<delete-config>", "<unlock>"},
+		},
+		"clear_rpc_error": {
+			replies:    map[string]string{"<delete-config>": fakeReplyRPCError},
+			wantRPC:    []string{"<delete-config>", "<unlock>", "<close-session/>"},
+			wantErrs:   []string{"failed to clear candidate configuration"},
+			wantClosed: true,
+		},
+		"unlock_rpc_error": {
+			replies:    map[string]string{"<unlock>": fakeReplyRPCError},
+			wantRPC:    []string{"<delete-config>", "<unlock>", "<close-session/>"},
+			wantErrs:   []string{"failed to unlock candidate configuration"},
+			wantClosed: true,
+		},
+		"clear_unlock_rpc_error": {
+			replies: map[string]string{
+				"<delete-config>": fakeReplyRPCError,
+				"<unlock>":        fakeReplyRPCError,
+			},
+			wantRPC:    []string{"<delete-config>", "<unlock>", "<close-session/>"},
+			wantErrs:   []string{"failed to clear candidate configuration", "failed to unlock candidate configuration"},
+			wantClosed: true,
+		},
+		"clear_transport_error": {
+			sendErrors: map[string]bool{"<delete-config>": true, "<unlock>": true, "<close-session/>": true},
+			wantRPC:    []string{"<delete-config>", "<unlock>", "<close-session/>"},
+			wantErrs: []string{
+				"failed to clear candidate configuration",
+				"failed to unlock candidate configuration",
+				"failed to close session",
+			},
+			wantClosed: true,
+		},
+		"private_close_ok": {
+			private: true,
+			wantRPC: []string{"<close-configuration/>"},
+		},
+		"private_close_rpc_error": {
+			private:    true,
+			replies:    map[string]string{"<close-configuration/>": fakeReplyRPCError},
+			wantRPC:    []string{"<close-configuration/>", "<close-session/>"},
+			wantErrs:   []string{"failed to clear candidate configuration"},
+			wantClosed: true,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			transport := newFakeTransport(tt.replies, tt.sendErrors)
+			jnpr := &NetconfObject{Session: &netconf.Session{Transport: transport}}
+			sess := &Session{junosConfigPrivate: tt.private}
+			err := sess.configClear(jnpr)
+			if !reflect.DeepEqual(transport.sentRPC, tt.wantRPC) {
+				t.Errorf("rpc sent = %v, want %v", transport.sentRPC, tt.wantRPC)
+			}
+			if transport.closed != tt.wantClosed {
+				t.Errorf("transport closed = %v, want %v", transport.closed, tt.wantClosed)
+			}
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+
+				return
+			}
+			var clearErr *configClearError
+			if !errors.As(err, &clearErr) {
+				t.Fatalf("error = %v, want a *configClearError", err)
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q doesn't contain %q", err.Error(), want)
+				}
+			}
+			if !jnpr.broken {
+				t.Errorf("session not marked broken after a failed clear")
+			}
+		})
+	}
+}
+
+func TestLockCandidateRetry(t *testing.T) {
+	const (
+		replyLockDenied = "<rpc-reply><rpc-error><error-tag>lock-denied</error-tag>" +
+			"<error-severity>error</error-severity><error-message>configuration database locked by:\n" +
+			"  admin terminal p0 (pid 1234) on since 2021-01-01 00:00:00 UTC\n" +
+			"      exclusive [edit]</error-message></rpc-error></rpc-reply>"
+		replyPermissionDenied = "<rpc-reply><rpc-error><error-tag>access-denied</error-tag>" +
+			"<error-severity>error</error-severity><error-message>permission denied</error-message>" +
+			"</rpc-error></rpc-reply>"
+	)
+	tests := map[string]struct {
+		reply       string
+		wantLockErr bool
+		wantErr     string
+	}{
+		"permission_denied": {
+			reply:   replyPermissionDenied,
+			wantErr: "permission denied",
+		},
+		"lock_denied": {
+			reply:       replyLockDenied,
+			wantLockErr: true,
+			wantErr:     "failed to lock candidate configuration, context deadline exceeded",
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			transport := newFakeTransport(map[string]string{"<lock>": tt.reply}, nil)
+			jnpr := &NetconfObject{Session: &netconf.Session{Transport: transport}}
+			// lock_timeout not set, only the end of ctx stops the retries of a lock denied
+			sess := &Session{junosSleepLock: 1}
+			ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			err := sess.lockCandidate(ctx, jnpr)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("lockCandidate() = %v, want error containing %q", err, tt.wantErr)
+			}
+			var lockErr *lockDeniedError
+			if errors.As(err, &lockErr) != tt.wantLockErr {
+				t.Errorf("lockCandidate() = %v, lock denied error %t, want %t", err, !tt.wantLockErr, tt.wantLockErr)
+			}
+			locks := 0
+			for _, rpc := range transport.sentRPC {
+				if rpc == "<lock>" {
+					locks++
+				}
+			}
+			if tt.wantLockErr && locks < 2 {
+				t.Errorf("lock sent %d time(s), want retries of lock denied", locks)
+			}
+			if !tt.wantLockErr && (locks != 1 || time.Since(start) > time.Second) {
+				t.Errorf("lock sent %d time(s) in %s, want one lock and an immediate error", locks, time.Since(start))
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package junos
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultActionTimeout : default timeout of resource actions (same as the default of Terraform SDK).
+const defaultActionTimeout = 20 * time.Minute
+
+// resourcesWithTimeouts adds the timeouts block to resources.
+// The context of each action ends with its timeout (or the interruption of Terraform)
+// and interrupts the netconf RPCs of action.
+func resourcesWithTimeouts(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, res := range resources {
+		if res.Timeouts != nil {
+			continue
+		}
+		res.Timeouts = &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultActionTimeout),
+			Read:   schema.DefaultTimeout(defaultActionTimeout),
+			Update: schema.DefaultTimeout(defaultActionTimeout),
+			Delete: schema.DefaultTimeout(defaultActionTimeout),
+		}
+	}
+
+	return resources
+}
@@ -0,0 +1,214 @@
+package junos
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// candidateState : lock (or private candidate configuration) and configuration lines loaded by a session,
+// restored on the new session after a transport failure.
+type candidateState struct {
+	locked  bool
+	private bool
+	lines   []string
+}
+
+func (j *NetconfObject) candidateState() candidateState {
+	return candidateState{
+		locked:  j.locked,
+		private: j.private,
+		lines:   append([]string(nil), j.loadedLines...),
+	}
+}
+
+// retryTransport runs action and, while it fails with a transport failure of the session
+// (not a rpc-error nor an interruption by the context), reconnects the session,
+// restores its candidate configuration and runs action again, at most transport_retry_count times.
+// action must be safe to replay (read-only rpc or load of configuration lines in the restored candidate).
+func (sess *Session) retryTransport(jnpr *NetconfObject, operation string, action func() error) error {
+	state := jnpr.candidateState()
+	err := action()
+	for attempt := 1; sess.canRetryTransport(jnpr, err, attempt); attempt++ {
+		if err = sess.reconnect(jnpr, operation, attempt, err); err != nil {
+			continue
+		}
+		if err = sess.restoreCandidate(jnpr, state); err != nil {
+			continue
+		}
+		err = action()
+	}
+
+	return err
+}
+
+// commitRetry runs commit with logMessage and, after a transport failure, reconnects the session
+// and reads 'show system commit' to know if the commit has been done before the failure, if not,
+// restores the candidate configuration and runs commit again.
+// To find the commit, a random token is added to logMessage.
+func (sess *Session) commitRetry(jnpr *NetconfObject, logMessage string,
+	commit func(logMessage string) ([]error, error)) (_warnings []error, _err error) {
+	if sess.junosRetryCount == 0 {
+		return commit(logMessage)
+	}
+	token, err := newCommitToken()
+	if err != nil {
+		return []error{}, err
+	}
+	logMessage = strings.TrimSpace(logMessage) + " [" + token + "]"
+	state := jnpr.candidateState()
+	warns, err := commit(logMessage)
+	for attempt := 1; sess.canRetryTransport(jnpr, err, attempt); attempt++ {
+		if err = sess.reconnect(jnpr, "commit", attempt, err); err != nil {
+			continue
+		}
+		var commits []string
+		commits, err = jnpr.netconfCommits()
+		if err != nil {
+			continue
+		}
+		if commitHasLog(commits, logMessage) {
+			// the session of the commit is closed so the lock is already released
+			jnpr.log().Info("commit done before transport failure", "log_message", logMessage, "attempt", attempt)
+
+			return []error{}, nil
+		}
+		jnpr.log().Info("commit not done before transport failure, commit again",
+			"log_message", logMessage, "attempt", attempt)
+		if err = sess.restoreCandidate(jnpr, state); err != nil {
+			continue
+		}
+		warns, err = commit(logMessage)
+	}
+
+	return warns, err
+}
+
+// newCommitToken returns a random token to find a commit in 'show system commit'.
+func newCommitToken() (string, error) {
+	token := make([]byte, 6)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate token of commit : %w", err)
+	}
+
+	return hex.EncodeToString(token), nil
+}
+
+// canRetryTransport returns true if err is a transport failure of jnpr (not an interruption by the context)
+// and attempt is allowed by transport_retry_count.
+func (sess *Session) canRetryTransport(jnpr *NetconfObject, err error, attempt int) bool {
+	return err != nil && jnpr.broken && !jnpr.interrupted && !jnpr.closed &&
+		attempt <= sess.junosRetryCount && jnpr.sessionContext().Err() == nil
+}
+
+// reconnect waits the backoff of attempt (doubled on each attempt from transport_retry_backoff)
+// then replaces the broken transport of jnpr by a new netconf session.
+func (sess *Session) reconnect(jnpr *NetconfObject, operation string, attempt int, cause error) error {
+	backoff := time.Duration(sess.junosRetryBackoff) * time.Second << (attempt - 1)
+	jnpr.log().Warn("netconf transport failure, reconnect and retry",
+		"operation", operation, "attempt", attempt, "backoff", backoff.String(), "error", cause)
+	_ = jnpr.Session.Transport.Close()
+	ctx := jnpr.sessionContext()
+	timer := time.NewTimer(backoff)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+
+		return fmt.Errorf("%w, reconnect stopped : %s", cause, ctx.Err())
+	case <-timer.C:
+	}
+	newJnpr, err := sess.dialNewSession(ctx)
+	if err != nil {
+		if newJnpr != nil {
+			_ = newJnpr.Close(sess.junosSleepSSHClosed)
+		}
+
+		return fmt.Errorf("%w, reconnect failed : %s", cause, err)
+	}
+	jnpr.Session = newJnpr.Session
+	jnpr.SystemInformation = newJnpr.SystemInformation
+	jnpr.broken = false
+	if jnpr.locked {
+		// the lock is released with the end of the failed session, so the commit slot kept since the lock
+		// (the slot of a commit with a private candidate configuration is released at the end of the commit)
+		jnpr.releaseCommit()
+	}
+	jnpr.locked = false
+	jnpr.private = false
+	jnpr.loadedLines = nil
+	jnpr.log().Info("netconf session reconnected", "operation", operation, "attempt", attempt)
+
+	return nil
+}
+
+// restoreCandidate locks the candidate configuration (or opens a private candidate configuration)
+// and loads the configuration lines like the session before its transport failure
+// (the device has discarded them with the end of the failed session).
+func (sess *Session) restoreCandidate(jnpr *NetconfObject, state candidateState) error {
+	switch {
+	case state.private && !sess.junosConfigPrivate:
+		// private candidate configuration of plan check
+		err := jnpr.netconfConfigOpenPrivate()
+		sleepShort(sess.junosSleepShort)
+		if err != nil {
+			return err
+		}
+	case state.locked || state.private:
+		if err := sess.lockCandidate(jnpr.sessionContext(), jnpr); err != nil {
+			return err
+		}
+	}
+	if len(state.lines) == 0 {
+		return nil
+	}
+	jnpr.log().Debug("reload configuration lines after reconnect", "lines", len(state.lines))
+
+	return sess.loadConfigLines(state.lines, jnpr)
+}
+
+// netconfCommits returns the commits (entries with their log message) in 'show system commit'.
+func (j *NetconfObject) netconfCommits() ([]string, error) {
+	read, err := j.netconfCommand("show system commit")
+	if read == emptyWord {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	commits := make([]string, 0)
+	commit := make([]string, 0)
+	for _, line := range strings.Split(strings.TrimSpace(read), "\n") {
+		if len(commit) > 0 && !strings.HasPrefix(line, " ") {
+			commits = append(commits, strings.Join(commit, "\n"))
+			commit = make([]string, 0)
+		}
+		if strings.TrimSpace(line) != "" {
+			commit = append(commit, strings.TrimSpace(line))
+		}
+	}
+	if len(commit) > 0 {
+		commits = append(commits, strings.Join(commit, "\n"))
+	}
+
+	return commits, nil
+}
+
+// commitHasLog returns true if one of commits (entries of 'show system commit') has been done
+// with netconf and logMessage.
+func commitHasLog(commits []string, logMessage string) bool {
+	for _, commit := range commits {
+		lines := strings.Split(commit, "\n")
+		if !strings.Contains(lines[0], "via netconf") {
+			continue
+		}
+		for _, line := range lines[1:] {
+			if line == strings.TrimSpace(logMessage) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,20 @@
+package junos
+
+import "testing"
+
+func TestCommitHasLog(t *testing.T) {
+	commits := []string{
+		"0   2021-02-10 10:10:12 UTC by admin via netconf\ncreate resource junos_vlan [0a1b2c3d4e5f]",
+		"1   2021-02-10 10:10:11 UTC by admin via netconf\ncreate resource junos_vlan [f5e4d3c2b1a0]",
+		"2   2021-02-10 10:10:10 UTC by admin via cli\ncreate resource junos_vlan [aaaaaaaaaaaa]",
+	}
+	if !commitHasLog(commits, "create resource junos_vlan [f5e4d3c2b1a0]") {
+		t.Errorf("commitHasLog(%q) of a previous commit = false, want true", commits)
+	}
+	if commitHasLog(commits, "create resource junos_vlan [bbbbbbbbbbbb]") {
+		t.Errorf("commitHasLog(%q) with another token = true, want false", commits)
+	}
+	if commitHasLog(commits, "create resource junos_vlan [aaaaaaaaaaaa]") {
+		t.Errorf("commitHasLog of a cli commit = true, want false")
+	}
+}
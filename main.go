@@ -1,13 +1,62 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
 	"terraform-provider-junos/junos"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		err := generate(os.Args[2:])
+		junos.CloseSessions()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+
+		return
+	}
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: junos.Provider,
+		GRPCProviderFunc: junos.GRPCProviderServer,
 	})
+	junos.CloseSessions()
+}
+
+// generate writes resource and import blocks for the configuration of the Junos device
+// configured with the provider environment variables.
+func generate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s generate [options]\n\n"+
+			"Generate resource and import blocks for the configuration of the Junos device\n"+
+			"configured with the JUNOS_* environment variables of the provider.\n\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	output := flags.String("output", "", "write to this file instead of standard output")
+	resources := flags.String("resources", "", "comma-separated list of resource types to generate (default all)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	var resourceTypes []string
+	if *resources != "" {
+		resourceTypes = strings.Split(*resources, ",")
+	}
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return junos.GenerateConfig(context.Background(), resourceTypes, w)
 }